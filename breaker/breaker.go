@@ -0,0 +1,280 @@
+// Package breaker implements a circuit breaker for results.
+package breaker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/clock"
+	"github.com/onur1/warp/event"
+)
+
+// ErrOpen is the error of a result which is rejected by an open circuit breaker.
+var ErrOpen = errors.New("breaker: circuit open")
+
+// A State is the state of a circuit breaker.
+type State int
+
+const (
+	// Closed lets every call through while counting failures.
+	Closed State = iota
+	// Open rejects every call with ErrOpen until the cooldown has passed.
+	Open
+	// HalfOpen lets a limited number of probe calls through, closing the breaker
+	// if they succeed and opening it again if any of them fails.
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// Config configures a circuit breaker. A threshold which is zero is disabled.
+type Config struct {
+	// ConsecutiveFailures is the number of consecutive failures after which a
+	// closed breaker opens.
+	ConsecutiveFailures int
+	// FailureRatio is the ratio of failing calls to all calls of a window after
+	// which a closed breaker opens, once the window has seen MinRequests calls.
+	FailureRatio float64
+	// MinRequests is the number of calls a window must see before FailureRatio
+	// is taken into account.
+	MinRequests int
+	// Window is the interval at which the counts of a closed breaker are reset.
+	// The counts are never reset while the breaker is closed if it is zero.
+	Window time.Duration
+	// Cooldown is how long an open breaker waits before letting probes through.
+	Cooldown time.Duration
+	// Probes is the number of concurrent calls a half-open breaker lets through,
+	// all of which must succeed for it to close. It defaults to 1.
+	Probes int
+	// IsFailure reports whether an error counts as a failure. By default every
+	// error does.
+	IsFailure func(error) bool
+}
+
+// A Transition represents a change in the state of a circuit breaker.
+type Transition struct {
+	From State
+	To   State
+	Time time.Time
+}
+
+// A CircuitBreaker stops running results which keep failing, rejecting them with
+// ErrOpen for a while before probing whether they have recovered. Time is told by
+// the clock carried by the context of the results. It is safe for concurrent use.
+type CircuitBreaker struct {
+	config Config
+
+	mu          sync.Mutex
+	state       State
+	generation  uint64
+	expires     time.Time
+	requests    int
+	failures    int
+	consecutive int
+	probes      int
+	successes   int
+
+	subject    *event.Subject[Transition]
+	queue      []Transition
+	delivering bool
+}
+
+// New creates a closed circuit breaker.
+func New(config Config) *CircuitBreaker {
+	if config.Probes <= 0 {
+		config.Probes = 1
+	}
+	if config.IsFailure == nil {
+		config.IsFailure = func(err error) bool {
+			return err != nil
+		}
+	}
+	return &CircuitBreaker{
+		config:  config,
+		subject: event.NewReplaySubject[Transition](1),
+	}
+}
+
+// State returns the state of the breaker as of its latest call. An open breaker
+// becomes half-open on the first call after its cooldown has passed.
+func (b *CircuitBreaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Event returns an event which emits the latest transition of the breaker, if
+// any, followed by the transitions after subscription, in order. Transitions are
+// delivered in the background, so that a slow subscriber doesn't hold up the
+// results wrapped by the breaker.
+func (b *CircuitBreaker) Event() warp.Event[Transition] {
+	return b.subject.Event()
+}
+
+// Wrap creates a result which runs a result through a circuit breaker, failing
+// with ErrOpen without running it if the breaker is open, or if it is half-open
+// and all of its probes are already in flight. A panic counts as a failure.
+func Wrap[A any](b *CircuitBreaker, ma warp.Result[A]) warp.Result[A] {
+	return func(ctx context.Context) (a A, err error) {
+		c := clock.FromContext(ctx)
+
+		generation, ok := b.before(c.Now())
+		if !ok {
+			return a, ErrOpen
+		}
+
+		finished := false
+
+		defer func() {
+			if !finished {
+				b.after(c.Now(), generation, false)
+			}
+		}()
+
+		a, err = ma(ctx)
+
+		finished = true
+
+		b.after(c.Now(), generation, !b.config.IsFailure(err))
+
+		return
+	}
+}
+
+// before admits a call, returning the generation it belongs to.
+func (b *CircuitBreaker) before(now time.Time) (uint64, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.update(now)
+
+	switch b.state {
+	case Open:
+		return 0, false
+	case HalfOpen:
+		if b.probes >= b.config.Probes {
+			return 0, false
+		}
+		b.probes++
+	}
+
+	b.requests++
+
+	return b.generation, true
+}
+
+// after records the outcome of a call, ignoring calls admitted before the latest
+// transition.
+func (b *CircuitBreaker) after(now time.Time, generation uint64, success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.update(now)
+
+	if generation != b.generation {
+		return
+	}
+
+	if success {
+		b.consecutive = 0
+		if b.state == HalfOpen {
+			b.successes++
+			if b.successes >= b.config.Probes {
+				b.transition(now, Closed)
+			}
+		}
+		return
+	}
+
+	b.failures++
+	b.consecutive++
+
+	switch {
+	case b.state == HalfOpen:
+		b.transition(now, Open)
+	case b.config.ConsecutiveFailures > 0 && b.consecutive >= b.config.ConsecutiveFailures:
+		b.transition(now, Open)
+	case b.config.FailureRatio > 0 && b.requests >= b.config.MinRequests &&
+		float64(b.failures) >= b.config.FailureRatio*float64(b.requests):
+		b.transition(now, Open)
+	}
+}
+
+// update moves an open breaker whose cooldown has passed to half-open, and resets
+// the counts of a closed breaker whose window has passed.
+func (b *CircuitBreaker) update(now time.Time) {
+	switch b.state {
+	case Closed:
+		switch {
+		case b.config.Window <= 0:
+		case b.expires.IsZero():
+			b.expires = now.Add(b.config.Window)
+		case !now.Before(b.expires):
+			b.reset(now)
+		}
+	case Open:
+		if !now.Before(b.expires) {
+			b.transition(now, HalfOpen)
+		}
+	}
+}
+
+func (b *CircuitBreaker) transition(now time.Time, state State) {
+	from := b.state
+
+	b.state = state
+	b.generation++
+	b.reset(now)
+
+	if state == Open {
+		b.expires = now.Add(b.config.Cooldown)
+	}
+
+	b.queue = append(b.queue, Transition{From: from, To: state, Time: now})
+
+	if !b.delivering {
+		b.delivering = true
+		go b.deliver()
+	}
+}
+
+func (b *CircuitBreaker) reset(now time.Time) {
+	b.requests, b.failures, b.consecutive = 0, 0, 0
+	b.probes, b.successes = 0, 0
+	b.expires = time.Time{}
+	if b.state == Closed && b.config.Window > 0 {
+		b.expires = now.Add(b.config.Window)
+	}
+}
+
+// deliver pushes the queued transitions to the subscribers until the queue is
+// empty.
+func (b *CircuitBreaker) deliver() {
+	for {
+		b.mu.Lock()
+		if len(b.queue) == 0 {
+			b.delivering = false
+			b.mu.Unlock()
+			return
+		}
+		t := b.queue[0]
+		b.queue = b.queue[1:]
+		b.mu.Unlock()
+
+		b.subject.Next(t)
+	}
+}
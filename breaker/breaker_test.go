@@ -0,0 +1,194 @@
+package breaker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/onur1/warp/breaker"
+	"github.com/onur1/warp/clock"
+	"github.com/onur1/warp/clock/clocktest"
+	"github.com/onur1/warp/result"
+	"github.com/stretchr/testify/assert"
+)
+
+var errFailed = errors.New("failed")
+
+type step struct {
+	advance     time.Duration
+	fail        bool
+	expectedErr error
+	state       breaker.State
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config breaker.Config
+		steps  []step
+	}{
+		{
+			desc:   "consecutive failures",
+			config: breaker.Config{ConsecutiveFailures: 2, Cooldown: time.Minute},
+			steps: []step{
+				{fail: true, expectedErr: errFailed, state: breaker.Closed},
+				{expectedErr: nil, state: breaker.Closed},
+				{fail: true, expectedErr: errFailed, state: breaker.Closed},
+				{fail: true, expectedErr: errFailed, state: breaker.Open},
+				{advance: 30 * time.Second, expectedErr: breaker.ErrOpen, state: breaker.Open},
+				{advance: 30 * time.Second, expectedErr: nil, state: breaker.Closed},
+			},
+		},
+		{
+			desc:   "failure ratio",
+			config: breaker.Config{FailureRatio: 0.5, MinRequests: 4, Cooldown: time.Minute},
+			steps: []step{
+				{fail: true, expectedErr: errFailed, state: breaker.Closed},
+				{fail: true, expectedErr: errFailed, state: breaker.Closed},
+				{expectedErr: nil, state: breaker.Closed},
+				{expectedErr: nil, state: breaker.Closed},
+				{fail: true, expectedErr: errFailed, state: breaker.Open},
+			},
+		},
+		{
+			desc:   "probe fails",
+			config: breaker.Config{ConsecutiveFailures: 1, Cooldown: time.Minute, Probes: 2},
+			steps: []step{
+				{fail: true, expectedErr: errFailed, state: breaker.Open},
+				{advance: time.Minute, expectedErr: nil, state: breaker.HalfOpen},
+				{fail: true, expectedErr: errFailed, state: breaker.Open},
+				{expectedErr: breaker.ErrOpen, state: breaker.Open},
+				{advance: time.Minute, expectedErr: nil, state: breaker.HalfOpen},
+				{expectedErr: nil, state: breaker.Closed},
+			},
+		},
+		{
+			desc:   "window",
+			config: breaker.Config{ConsecutiveFailures: 2, Window: time.Minute, Cooldown: time.Minute},
+			steps: []step{
+				{fail: true, expectedErr: errFailed, state: breaker.Closed},
+				{advance: time.Minute, fail: true, expectedErr: errFailed, state: breaker.Closed},
+				{fail: true, expectedErr: errFailed, state: breaker.Open},
+			},
+		},
+		{
+			desc: "IsFailure",
+			config: breaker.Config{
+				ConsecutiveFailures: 1,
+				IsFailure: func(err error) bool {
+					return err != nil && !errors.Is(err, errFailed)
+				},
+			},
+			steps: []step{
+				{fail: true, expectedErr: errFailed, state: breaker.Closed},
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var (
+				c   = clocktest.NewClock(time.Unix(0, 0))
+				ctx = clock.WithClock(context.Background(), c)
+				b   = breaker.New(tC.config)
+			)
+
+			for i, s := range tC.steps {
+				c.Advance(s.advance)
+
+				ma := result.Ok(42)
+				if s.fail {
+					ma = result.Error[int](errFailed)
+				}
+
+				_, err := breaker.Wrap(b, ma)(ctx)
+
+				assert.Equal(t, s.expectedErr, err, "step %d", i)
+				assert.Equal(t, s.state, b.State(), "step %d", i)
+			}
+		})
+	}
+}
+
+func TestProbes(t *testing.T) {
+	var (
+		c       = clocktest.NewClock(time.Unix(0, 0))
+		ctx     = clock.WithClock(context.Background(), c)
+		b       = breaker.New(breaker.Config{ConsecutiveFailures: 1, Cooldown: time.Minute})
+		started = make(chan struct{})
+		gate    = make(chan struct{})
+	)
+
+	_, err := breaker.Wrap(b, result.Error[int](errFailed))(ctx)
+	assert.Equal(t, errFailed, err)
+
+	c.Advance(time.Minute)
+
+	errc := make(chan error)
+
+	go func() {
+		_, err := breaker.Wrap(b, func(context.Context) (int, error) {
+			close(started)
+			<-gate
+			return 42, nil
+		})(ctx)
+		errc <- err
+	}()
+
+	<-started
+
+	_, err = breaker.Wrap(b, result.Ok(42))(ctx)
+	assert.Equal(t, breaker.ErrOpen, err)
+	assert.Equal(t, breaker.HalfOpen, b.State())
+
+	close(gate)
+
+	assert.NoError(t, <-errc)
+	assert.Equal(t, breaker.Closed, b.State())
+}
+
+func TestPanic(t *testing.T) {
+	b := breaker.New(breaker.Config{ConsecutiveFailures: 1, Cooldown: time.Minute})
+
+	assert.Panics(t, func() {
+		_, _ = breaker.Wrap(b, func(context.Context) (int, error) {
+			panic("boom")
+		})(context.TODO())
+	})
+
+	assert.Equal(t, breaker.Open, b.State())
+}
+
+func TestEvent(t *testing.T) {
+	var (
+		c   = clocktest.NewClock(time.Unix(0, 0))
+		ctx = clock.WithClock(context.Background(), c)
+		b   = breaker.New(breaker.Config{ConsecutiveFailures: 1, Cooldown: time.Minute})
+	)
+
+	_, _ = breaker.Wrap(b, result.Error[int](errFailed))(ctx)
+
+	sub, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ts := make(chan breaker.Transition)
+
+	go b.Event()(sub, ts)
+
+	var transitions []breaker.Transition
+
+	// The latest transition is replayed once the subscription is registered.
+	transitions = append(transitions, <-ts)
+
+	c.Advance(time.Minute)
+
+	_, _ = breaker.Wrap(b, result.Ok(42))(ctx)
+
+	transitions = append(transitions, <-ts, <-ts)
+
+	assert.Equal(t, []breaker.Transition{
+		{From: breaker.Closed, To: breaker.Open, Time: time.Unix(0, 0)},
+		{From: breaker.Open, To: breaker.HalfOpen, Time: time.Unix(60, 0)},
+		{From: breaker.HalfOpen, To: breaker.Closed, Time: time.Unix(60, 0)},
+	}, transitions)
+}
@@ -0,0 +1,97 @@
+// Package clock provides an abstraction of time which is used by the time-based
+// constructors and operators of warp, so that they can be driven by a virtual
+// clock in tests.
+package clock
+
+import (
+	"context"
+	"time"
+)
+
+// A Clock tells the current time and creates timers and tickers.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// NewTimer creates a timer which sends the current time on its channel after
+	// at least the specified duration.
+	NewTimer(dur time.Duration) Timer
+	// NewTicker creates a ticker which sends the current time on its channel
+	// periodically.
+	NewTicker(dur time.Duration) Ticker
+}
+
+// A Timer represents a single event which is sent on a channel.
+type Timer interface {
+	// C returns the channel on which the time is delivered.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing, and reports whether it was stopped
+	// before it fired.
+	Stop() bool
+}
+
+// A Ticker represents a periodic event which is sent on a channel.
+type Ticker interface {
+	// C returns the channel on which the ticks are delivered.
+	C() <-chan time.Time
+	// Stop turns off the ticker.
+	Stop()
+}
+
+// Real is the clock of the system, backed by the time package.
+var Real Clock = realClock{}
+
+type clockKey struct{}
+
+// WithClock returns a copy of a context which carries the supplied clock.
+func WithClock(ctx context.Context, c Clock) context.Context {
+	return context.WithValue(ctx, clockKey{}, c)
+}
+
+// FromContext returns the clock carried by a context, or Real if there is none.
+// A nil context is allowed.
+func FromContext(ctx context.Context) Clock {
+	if ctx != nil {
+		if c, ok := ctx.Value(clockKey{}).(Clock); ok {
+			return c
+		}
+	}
+	return Real
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(dur time.Duration) Timer {
+	return realTimer{time.NewTimer(dur)}
+}
+
+func (realClock) NewTicker(dur time.Duration) Ticker {
+	return realTicker{time.NewTicker(dur)}
+}
+
+type realTimer struct {
+	t *time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.t.C
+}
+
+func (t realTimer) Stop() bool {
+	return t.t.Stop()
+}
+
+type realTicker struct {
+	t *time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time {
+	return t.t.C
+}
+
+func (t realTicker) Stop() {
+	t.t.Stop()
+}
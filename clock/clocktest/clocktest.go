@@ -0,0 +1,164 @@
+// Package clocktest provides a virtual clock for testing time-based events.
+package clocktest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/onur1/warp/clock"
+)
+
+// A Clock is a virtual clock which only moves forward when it is advanced. It
+// implements the clock.Clock interface and is safe for concurrent use.
+type Clock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*waiter
+}
+
+// NewClock creates a virtual clock which is set to the supplied time.
+func NewClock(now time.Time) *Clock {
+	c := &Clock{now: now}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now returns the current virtual time.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTimer creates a timer which fires once the clock has been advanced by at
+// least the specified duration.
+func (c *Clock) NewTimer(dur time.Duration) clock.Timer {
+	return c.add(dur, 0)
+}
+
+// NewTicker creates a ticker which fires each time the clock has been advanced
+// by the specified duration.
+func (c *Clock) NewTicker(dur time.Duration) clock.Ticker {
+	if dur <= 0 {
+		panic("clocktest: non-positive interval for NewTicker")
+	}
+	return ticker{c.add(dur, dur)}
+}
+
+// Advance moves the clock forward by the specified duration, firing every timer
+// and ticker which is due in chronological order.
+func (c *Clock) Advance(dur time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	end := c.now.Add(dur)
+
+	for {
+		w := c.next()
+		if w == nil || w.when.After(end) {
+			break
+		}
+
+		c.now = w.when
+
+		select {
+		case w.c <- c.now:
+		default:
+		}
+
+		if w.period > 0 {
+			w.when = w.when.Add(w.period)
+		} else {
+			c.remove(w)
+		}
+	}
+
+	c.now = end
+}
+
+// Len returns the number of timers and tickers which are waiting to fire.
+func (c *Clock) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+// BlockUntil blocks until at least n timers and tickers are waiting to fire.
+func (c *Clock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
+
+func (c *Clock) add(dur, period time.Duration) *waiter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w := &waiter{
+		clock:  c,
+		c:      make(chan time.Time, 1),
+		when:   c.now.Add(dur),
+		period: period,
+	}
+
+	if period == 0 && dur <= 0 {
+		w.c <- c.now
+		return w
+	}
+
+	c.waiters = append(c.waiters, w)
+	c.cond.Broadcast()
+
+	return w
+}
+
+// next returns the waiter which is due first, preferring the one which was
+// created first among the ones due at the same time.
+func (c *Clock) next() *waiter {
+	var first *waiter
+	for _, w := range c.waiters {
+		if first == nil || w.when.Before(first.when) {
+			first = w
+		}
+	}
+	return first
+}
+
+func (c *Clock) remove(w *waiter) bool {
+	for i, v := range c.waiters {
+		if v == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			c.cond.Broadcast()
+			return true
+		}
+	}
+	return false
+}
+
+type waiter struct {
+	clock  *Clock
+	c      chan time.Time
+	when   time.Time
+	period time.Duration
+}
+
+func (w *waiter) C() <-chan time.Time {
+	return w.c
+}
+
+func (w *waiter) Stop() bool {
+	w.clock.mu.Lock()
+	defer w.clock.mu.Unlock()
+	return w.clock.remove(w)
+}
+
+type ticker struct {
+	*waiter
+}
+
+func (t ticker) Stop() {
+	t.waiter.Stop()
+}
@@ -0,0 +1,88 @@
+package clocktest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onur1/warp/clock/clocktest"
+	"github.com/stretchr/testify/assert"
+)
+
+var epoch = time.Unix(0, 0)
+
+func TestClock(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		run      func(c *clocktest.Clock) []time.Time
+		expected []time.Time
+	}{
+		{
+			desc: "Timer",
+			run: func(c *clocktest.Clock) []time.Time {
+				timer := c.NewTimer(time.Second)
+				c.Advance(time.Millisecond * 500)
+				c.Advance(time.Second)
+				return drain(timer.C())
+			},
+			expected: []time.Time{epoch.Add(time.Second)},
+		},
+		{
+			desc: "Timer (stopped)",
+			run: func(c *clocktest.Clock) []time.Time {
+				timer := c.NewTimer(time.Second)
+				assert.True(t, timer.Stop())
+				c.Advance(time.Second)
+				return drain(timer.C())
+			},
+		},
+		{
+			desc: "Ticker",
+			run: func(c *clocktest.Clock) []time.Time {
+				ticker := c.NewTicker(time.Second)
+				defer ticker.Stop()
+				var ts []time.Time
+				for i := 0; i < 3; i++ {
+					c.Advance(time.Second)
+					ts = append(ts, drain(ticker.C())...)
+				}
+				return ts
+			},
+			expected: []time.Time{epoch.Add(time.Second), epoch.Add(time.Second * 2), epoch.Add(time.Second * 3)},
+		},
+		{
+			desc: "Now",
+			run: func(c *clocktest.Clock) []time.Time {
+				c.Advance(time.Minute)
+				return []time.Time{c.Now()}
+			},
+			expected: []time.Time{epoch.Add(time.Minute)},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			assert.Equal(t, tC.expected, tC.run(clocktest.NewClock(epoch)))
+		})
+	}
+}
+
+func TestBlockUntil(t *testing.T) {
+	c := clocktest.NewClock(epoch)
+
+	go c.NewTimer(time.Second)
+
+	c.BlockUntil(1)
+
+	assert.Equal(t, 1, c.Len())
+}
+
+func drain(c <-chan time.Time) []time.Time {
+	var ts []time.Time
+	for {
+		select {
+		case t := <-c:
+			ts = append(ts, t)
+		default:
+			return ts
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package event
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/internal/flow"
+)
+
+// BufferCount creates an event which collects values from a source event and emits
+// them as a slice each time n values have been received. A partially filled buffer
+// is emitted when the source event ends, and discarded when the context is
+// cancelled. If n is not positive, the values are collected until the source event
+// ends.
+func BufferCount[A any](fa warp.Event[A], n int) warp.Event[[]A] {
+	return buffer(fa, n, 0)
+}
+
+// BufferTime creates an event which collects values from a source event and emits
+// them as a slice once the specified duration has passed since the first value
+// of the buffer was received. A partially filled buffer is emitted when the source
+// event ends, and discarded when the context is cancelled. If dur is not positive,
+// the values are collected until the source event ends.
+func BufferTime[A any](fa warp.Event[A], dur time.Duration) warp.Event[[]A] {
+	return buffer(fa, 0, dur)
+}
+
+// BufferCountOrTime creates an event which collects values from a source event and
+// emits them as a slice either when n values have been received, or when the
+// specified duration has passed since the first value of the buffer was received,
+// whichever happens first. A partially filled buffer is emitted when the source
+// event ends, and discarded when the context is cancelled. A limit which is not
+// positive is ignored, and the values are collected until the source event ends
+// if neither is positive.
+func BufferCountOrTime[A any](fa warp.Event[A], n int, dur time.Duration) warp.Event[[]A] {
+	return buffer(fa, n, dur)
+}
+
+// WindowCount is like BufferCount but it emits an event for each window as soon as
+// its first value is received, which emits the values of the window as they are
+// received and ends once n values have been received. Each window may be
+// subscribed to only once. Its values are buffered until they are received, so
+// that a window which is not subscribed to, or whose subscriber is slow, doesn't
+// hold up the source event. The values of a window whose subscription has been
+// cancelled are dropped.
+func WindowCount[A any](fa warp.Event[A], n int) warp.Event[warp.Event[A]] {
+	return window(fa, n, 0)
+}
+
+// WindowTime is like WindowCount but each window ends once the specified duration
+// has passed since its first value was received.
+func WindowTime[A any](fa warp.Event[A], dur time.Duration) warp.Event[warp.Event[A]] {
+	return window(fa, 0, dur)
+}
+
+// WindowCountOrTime is like WindowCount but each window ends either when n values
+// have been received, or when the specified duration has passed since its first
+// value was received, whichever happens first.
+func WindowCountOrTime[A any](fa warp.Event[A], n int, dur time.Duration) warp.Event[warp.Event[A]] {
+	return window(fa, n, dur)
+}
+
+// buffer collects values from a source event into slices which are flushed when
+// they hold n values, or when dur has passed since their first value. A limit
+// which is not positive is ignored.
+func buffer[A any](fa warp.Event[A], n int, dur time.Duration) warp.Event[[]A] {
+	return func(ctx context.Context, sub chan<- []A) {
+		defer close(sub)
+
+		var (
+			as  = make(chan A)
+			d   = newDelay(ctx)
+			a   A
+			buf []A
+			ok  bool
+		)
+
+		var done <-chan struct{}
+
+		if ctx != nil {
+			done = ctx.Done()
+		}
+
+		defer d.Stop()
+
+		go fa(ctx, as)
+
+		for {
+			select {
+			case <-done:
+				return
+			case a, ok = <-as:
+				if !ok {
+					if len(buf) > 0 {
+						flow.Emit(done, sub, buf)
+					}
+					return
+				}
+				buf = append(buf, a)
+				if n > 0 && len(buf) >= n {
+					d.Stop()
+					if !flow.Emit(done, sub, buf) {
+						return
+					}
+					buf = nil
+					break
+				}
+				if dur > 0 && d.C == nil {
+					d.Reset(dur)
+				}
+			case <-d.C:
+				d.Stop()
+				if !flow.Emit(done, sub, buf) {
+					return
+				}
+				buf = nil
+			}
+		}
+	}
+}
+
+// window splits the values of a source event into windows which end when they
+// hold n values, or when dur has passed since their first value. A limit which is
+// not positive is ignored.
+func window[A any](fa warp.Event[A], n int, dur time.Duration) warp.Event[warp.Event[A]] {
+	return func(ctx context.Context, sub chan<- warp.Event[A]) {
+		defer close(sub)
+
+		var (
+			as    = make(chan A)
+			d     = newDelay(ctx)
+			a     A
+			w     *windowed[A]
+			count int
+			ok    bool
+		)
+
+		var done <-chan struct{}
+
+		if ctx != nil {
+			done = ctx.Done()
+		}
+
+		defer d.Stop()
+
+		defer func() {
+			if w != nil {
+				w.end()
+			}
+		}()
+
+		go fa(ctx, as)
+
+		for {
+			select {
+			case <-done:
+				return
+			case a, ok = <-as:
+				if !ok {
+					return
+				}
+				if w == nil {
+					w, count = newWindowed[A](), 0
+					if !flow.Emit(done, sub, w.event) {
+						return
+					}
+					if dur > 0 {
+						d.Reset(dur)
+					}
+				}
+				w.forward(a)
+				count += 1
+				if n > 0 && count >= n {
+					d.Stop()
+					w.end()
+					w = nil
+				}
+			case <-d.C:
+				d.Stop()
+				w.end()
+				w = nil
+			}
+		}
+	}
+}
+
+// windowed is a window whose values are buffered until its subscriber receives
+// them.
+type windowed[A any] struct {
+	mu        sync.Mutex
+	values    []A
+	ended     bool
+	abandoned bool
+	ready     chan struct{}
+	event     warp.Event[A]
+}
+
+func newWindowed[A any]() *windowed[A] {
+	w := &windowed[A]{
+		ready: make(chan struct{}, 1),
+	}
+
+	w.event = func(ctx context.Context, sub chan<- A) {
+		defer close(sub)
+
+		defer func() {
+			w.mu.Lock()
+			w.values, w.abandoned = nil, true
+			w.mu.Unlock()
+		}()
+
+		var done <-chan struct{}
+
+		if ctx != nil {
+			done = ctx.Done()
+		}
+
+		for {
+			w.mu.Lock()
+			values, ended := w.values, w.ended
+			w.values = nil
+			w.mu.Unlock()
+
+			for _, a := range values {
+				if !flow.Emit(done, sub, a) {
+					return
+				}
+			}
+
+			if ended {
+				return
+			}
+
+			select {
+			case <-done:
+				return
+			case <-w.ready:
+			}
+		}
+	}
+
+	return w
+}
+
+// forward buffers a value for the subscriber of the window, dropping it if the
+// subscription has ended.
+func (w *windowed[A]) forward(a A) {
+	w.mu.Lock()
+	if !w.abandoned {
+		w.values = append(w.values, a)
+	}
+	w.mu.Unlock()
+	w.signal()
+}
+
+// end ends the window once its buffered values have been received.
+func (w *windowed[A]) end() {
+	w.mu.Lock()
+	w.ended = true
+	w.mu.Unlock()
+	w.signal()
+}
+
+// signal wakes up the subscriber of the window, if it is waiting.
+func (w *windowed[A]) signal() {
+	select {
+	case w.ready <- struct{}{}:
+	default:
+	}
+}
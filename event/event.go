@@ -6,17 +6,25 @@ import (
 	"time"
 
 	"github.com/onur1/warp"
+	"github.com/onur1/warp/clock"
 	"github.com/onur1/warp/nilable"
 )
 
 // Map creates an event by applying a function on each value received from a source
 // event.
 func Map[A, B any](fa warp.Event[A], f func(A) B) warp.Event[B] {
+	return MapBuffered(fa, f, 0)
+}
+
+// MapBuffered is like Map but it receives the values of the source event through a
+// channel which buffers up to n values, letting the source event run ahead while f
+// or the subscriber is busy.
+func MapBuffered[A, B any](fa warp.Event[A], f func(A) B, n int) warp.Event[B] {
 	return func(ctx context.Context, sub chan<- B) {
 		defer close(sub)
 
 		var (
-			as = make(chan A)
+			as = make(chan A, max(n, 0))
 			a  A
 		)
 
@@ -350,11 +358,18 @@ func Alt[A any](x warp.Event[A], y warp.Event[A]) warp.Event[A] {
 
 // Filter creates an event which emits values from a source event when a predicate holds.
 func Filter[A any](fa warp.Event[A], predicate warp.Predicate[A]) warp.Event[A] {
+	return FilterBuffered(fa, predicate, 0)
+}
+
+// FilterBuffered is like Filter but it receives the values of the source event
+// through a channel which buffers up to n values, letting the source event run
+// ahead while the subscriber is busy.
+func FilterBuffered[A any](fa warp.Event[A], predicate warp.Predicate[A], n int) warp.Event[A] {
 	return func(ctx context.Context, sub chan<- A) {
 		defer close(sub)
 
 		var (
-			as = make(chan A)
+			as = make(chan A, max(n, 0))
 			a  A
 		)
 
@@ -383,12 +398,21 @@ func Filter[A any](fa warp.Event[A], predicate warp.Predicate[A]) warp.Event[A]
 	}
 }
 
+// FilterMap creates an event by applying a function on each value received from a
+// source event, emitting the values which are not nil.
 func FilterMap[A, B any](fa warp.Event[A], f func(a A) warp.Nilable[B]) warp.Event[B] {
+	return FilterMapBuffered(fa, f, 0)
+}
+
+// FilterMapBuffered is like FilterMap but it receives the values of the source
+// event through a channel which buffers up to n values, letting the source event
+// run ahead while f or the subscriber is busy.
+func FilterMapBuffered[A, B any](fa warp.Event[A], f func(a A) warp.Nilable[B], n int) warp.Event[B] {
 	return func(ctx context.Context, sub chan<- B) {
 		defer close(sub)
 
 		var (
-			as = make(chan A)
+			as = make(chan A, max(n, 0))
 			a  A
 			nb warp.Nilable[B]
 		)
@@ -549,11 +573,18 @@ func Once[A any](fa warp.Event[A], predicate warp.Predicate[A]) warp.Event[A] {
 // Fold creates an event which combines the values from a source event by applying
 // a function starting with an initial value.
 func Fold[A, B any](fa warp.Event[A], b B, f func(A, B) B) warp.Event[B] {
+	return FoldBuffered(fa, b, f, 0)
+}
+
+// FoldBuffered is like Fold but it receives the values of the source event through
+// a channel which buffers up to n values, letting the source event run ahead while
+// f or the subscriber is busy.
+func FoldBuffered[A, B any](fa warp.Event[A], b B, f func(A, B) B, n int) warp.Event[B] {
 	return func(ctx context.Context, sub chan<- B) {
 		defer close(sub)
 
 		var (
-			as     = make(chan A)
+			as     = make(chan A, max(n, 0))
 			a      A
 			result = b
 		)
@@ -605,13 +636,14 @@ func Of[A any](a A) warp.Event[A] {
 	}
 }
 
-// Interval creates an event which emits the current time periodically.
+// Interval creates an event which emits the current time periodically, as told
+// by the clock carried by the context.
 func Interval(dur time.Duration) warp.Event[time.Time] {
 	return func(ctx context.Context, sub chan<- time.Time) {
 		defer close(sub)
 
 		var (
-			ticker = time.NewTicker(dur)
+			ticker = clock.FromContext(ctx).NewTicker(dur)
 			t      time.Time
 			ok     bool
 		)
@@ -633,7 +665,7 @@ func Interval(dur time.Duration) warp.Event[time.Time] {
 				select {
 				case <-done:
 					break LOOP
-				case t, ok = <-ticker.C:
+				case t, ok = <-ticker.C():
 					if !ok {
 						break LOOP
 					}
@@ -727,7 +759,7 @@ func After[A any](dur time.Duration, a A) warp.Event[A] {
 		defer close(sub)
 
 		var (
-			ticker = time.NewTicker(dur)
+			timer = clock.FromContext(ctx).NewTimer(dur)
 		)
 
 		var done <-chan struct{}
@@ -736,7 +768,7 @@ func After[A any](dur time.Duration, a A) warp.Event[A] {
 			done = ctx.Done()
 		}
 
-		defer ticker.Stop()
+		defer timer.Stop()
 
 	LOOP:
 		for {
@@ -747,7 +779,7 @@ func After[A any](dur time.Duration, a A) warp.Event[A] {
 				select {
 				case <-done:
 					break LOOP
-				case <-ticker.C:
+				case <-timer.C():
 					select {
 					case <-done:
 						break LOOP
@@ -825,7 +857,8 @@ type Time[A any] struct {
 	Time  time.Time
 }
 
-// WithTime creates an event which reports the current local time.
+// WithTime creates an event which reports the current time, as told by the
+// clock carried by the context.
 func WithTime[A any](fa warp.Event[A]) warp.Event[Time[A]] {
 	return func(ctx context.Context, sub chan<- Time[A]) {
 		defer close(sub)
@@ -833,6 +866,7 @@ func WithTime[A any](fa warp.Event[A]) warp.Event[Time[A]] {
 		var (
 			as = make(chan A)
 			a  A
+			c  = clock.FromContext(ctx)
 		)
 
 		var done <-chan struct{}
@@ -851,7 +885,7 @@ func WithTime[A any](fa warp.Event[A]) warp.Event[Time[A]] {
 				select {
 				case <-done:
 					return
-				case sub <- Time[A]{Value: a, Time: time.Now()}:
+				case sub <- Time[A]{Value: a, Time: c.Now()}:
 				}
 			}
 		}
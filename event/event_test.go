@@ -2,12 +2,22 @@ package event_test
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"sort"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/onur1/warp"
+	"github.com/onur1/warp/clock"
+	"github.com/onur1/warp/clock/clocktest"
 	"github.com/onur1/warp/event"
+	"github.com/onur1/warp/limiter"
 	"github.com/onur1/warp/nilable"
+	"github.com/onur1/warp/result"
+	"github.com/onur1/warp/warptest"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -102,6 +112,54 @@ func TestEvent(t *testing.T) {
 			event:    event.FilterMap(event.From([]int{-3, 4, -1, 5, 0, 6}), doublePositive),
 			expected: []int{8, 10, 12},
 		},
+		{
+			desc:     "Fuse",
+			event:    event.Fuse(event.From([]int{-2, 1, -3, 2}), doubleFilterDouble()),
+			expected: []int{4, 8},
+		},
+		{
+			desc:     "Fuse (FilterMap)",
+			event:    event.Fuse(event.From([]int{-1, 2, 3}), event.FilterMapOp(doublePositive)),
+			expected: []int{4, 6},
+		},
+		{
+			desc:     "MapBuffered",
+			event:    event.MapBuffered(event.From([]int{1, 2, 3}), double, 2),
+			expected: []int{2, 4, 6},
+		},
+		{
+			desc:     "FilterBuffered",
+			event:    event.FilterBuffered(event.From([]int{-3, 4, -1, 5}), isPositive, 2),
+			expected: []int{4, 5},
+		},
+		{
+			desc:     "FilterMapBuffered",
+			event:    event.FilterMapBuffered(event.From([]int{-3, 4, -1, 5}), doublePositive, 2),
+			expected: []int{8, 10},
+		},
+		{
+			desc:     "FoldBuffered",
+			event:    event.FoldBuffered(event.From([]int{1, 2}), 5, add, 2),
+			expected: []int{6, 8},
+		},
+		{
+			desc:     "FuseBuffered",
+			event:    event.FuseBuffered(event.From([]int{-2, 1, -3, 2}), doubleFilterDouble(), 2),
+			expected: []int{4, 8},
+		},
+		{
+			desc:     "Zip",
+			event:    event.Map(event.Zip(event.From([]int{1, 2, 3}), event.From([]int{10, 20})), sum),
+			expected: []int{11, 22},
+		},
+		{
+			desc: "Zip3",
+			event: event.Map(
+				event.Zip3(event.From([]int{1, 2}), event.From([]int{10, 20}), event.From([]int{100, 200, 300})),
+				sum3,
+			),
+			expected: []int{111, 222},
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
@@ -110,6 +168,844 @@ func TestEvent(t *testing.T) {
 	}
 }
 
+func TestBuffer(t *testing.T) {
+	buffers := map[rune][]int{'p': {1, 2}, 'q': {3, 4}, 'r': {5}, 's': {3}, 't': {4, 5}}
+
+	testCases := []struct {
+		desc     string
+		event    func(s *warptest.Scheduler) warp.Event[[]int]
+		expected string
+	}{
+		{
+			desc: "BufferCount",
+			event: func(s *warptest.Scheduler) warp.Event[[]int] {
+				return event.BufferCount(warptest.Cold(s, "-abcde|", values), 2)
+			},
+			expected: "--p-q-(r|)",
+		},
+		{
+			desc: "BufferTime",
+			event: func(s *warptest.Scheduler) warp.Event[[]int] {
+				return event.BufferTime(warptest.Cold(s, "-ab-----cd|", values), s.Frames(3))
+			},
+			expected: "----p-----(q|)",
+		},
+		{
+			desc: "BufferCountOrTime",
+			event: func(s *warptest.Scheduler) warp.Event[[]int] {
+				return event.BufferCountOrTime(warptest.Cold(s, "-abc-----de|", values), 2, s.Frames(3))
+			},
+			expected: "--p---s---t|",
+		},
+		{
+			desc: "WindowCount",
+			event: func(s *warptest.Scheduler) warp.Event[[]int] {
+				return event.Map(event.WindowCount(warptest.Cold(s, "-abcde|", values), 2), collect[int])
+			},
+			expected: "--p-q-(r|)",
+		},
+		{
+			desc: "WindowTime",
+			event: func(s *warptest.Scheduler) warp.Event[[]int] {
+				return event.Map(event.WindowTime(warptest.Cold(s, "-ab-----cd|", values), s.Frames(3)), collect[int])
+			},
+			expected: "----p-----(q|)",
+		},
+		{
+			desc: "WindowCountOrTime",
+			event: func(s *warptest.Scheduler) warp.Event[[]int] {
+				return event.Map(event.WindowCountOrTime(warptest.Cold(s, "-abc-----de|", values), 2, s.Frames(3)), collect[int])
+			},
+			expected: "--p---s---t|",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := warptest.NewScheduler()
+			warptest.Expect(t, s, tC.event(s), tC.expected, buffers)
+		})
+	}
+}
+
+func TestWindow(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		event    func(s *warptest.Scheduler) warp.Event[warp.Event[int]]
+		expected string
+	}{
+		{
+			desc: "WindowCount",
+			event: func(s *warptest.Scheduler) warp.Event[warp.Event[int]] {
+				return event.WindowCount(warptest.Cold(s, "-a-b-c-|", values), 2)
+			},
+			expected: "-a-b-c-|",
+		},
+		{
+			desc: "WindowTime",
+			event: func(s *warptest.Scheduler) warp.Event[warp.Event[int]] {
+				return event.WindowTime(warptest.Cold(s, "-ab-----cd|", values), s.Frames(3))
+			},
+			expected: "-ab-----cd|",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := warptest.NewScheduler()
+			flattened := event.Chain(tC.event(s), func(w warp.Event[int]) warp.Event[int] {
+				return w
+			})
+			warptest.Expect(t, s, flattened, tC.expected, values)
+		})
+	}
+}
+
+func TestWindowIgnored(t *testing.T) {
+	ones := map[rune]int{'x': 1, 'y': 2}
+
+	testCases := []struct {
+		desc     string
+		event    func(s *warptest.Scheduler) warp.Event[int]
+		expected string
+	}{
+		{
+			desc: "WindowCount",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.Map(event.WindowCount(warptest.Cold(s, "-abcde|", values), 2), func(warp.Event[int]) int {
+					return 1
+				})
+			},
+			expected: "-x-x-x|",
+		},
+		{
+			desc: "WindowTime",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.Map(event.WindowTime(warptest.Cold(s, "-ab-----cd|", values), s.Frames(3)), func(warp.Event[int]) int {
+					return 1
+				})
+			},
+			expected: "-x------x-|",
+		},
+		{
+			desc: "WindowTime (late subscriber)",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				windows := event.WindowTime(warptest.Cold(s, "-ab-----cd|", values), s.Frames(3))
+				return event.Map(event.BufferCount(windows, 0), func(ws []warp.Event[int]) int {
+					return len(collect(ws[0]))
+				})
+			},
+			expected: "----------(y|)",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := warptest.NewScheduler()
+			warptest.Expect(t, s, tC.event(s), tC.expected, ones)
+		})
+	}
+
+	assert.Equal(t, 3, event.CountAll(context.TODO(), event.WindowCount(event.From([]int{1, 2, 3, 4, 5, 6}), 2)))
+}
+
+func TestBufferCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+	defer cancel()
+
+	r := make(chan []int)
+
+	go event.BufferCount(delayed([]int{1, 2}, []int{0, 0, 1000}), 3)(ctx, r)
+
+	_, ok := <-r
+
+	assert.False(t, ok)
+}
+
+func TestVirtualTime(t *testing.T) {
+	c := clocktest.NewClock(time.Unix(0, 0))
+
+	ctx, cancel := context.WithCancel(clock.WithClock(context.Background(), c))
+	defer cancel()
+
+	r := make(chan time.Time)
+
+	go event.Interval(time.Second)(ctx, r)
+
+	c.BlockUntil(1)
+
+	for i := 1; i <= 3; i++ {
+		c.Advance(time.Second)
+		assert.Equal(t, time.Unix(int64(i), 0), <-r)
+	}
+
+	cancel()
+
+	_, ok := <-r
+
+	assert.False(t, ok)
+}
+
+func TestSubject(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		subject  *event.Subject[int]
+		values   []int
+		expected []int
+	}{
+		{
+			desc:     "Subject",
+			subject:  event.NewSubject[int](),
+			values:   []int{1, 2, 3},
+			expected: nil,
+		},
+		{
+			desc:     "ReplaySubject",
+			subject:  event.NewReplaySubject[int](2),
+			values:   []int{1, 2, 3},
+			expected: []int{2, 3},
+		},
+		{
+			desc:     "BehaviorSubject",
+			subject:  event.NewBehaviorSubject(0),
+			expected: []int{0},
+		},
+		{
+			desc:     "BehaviorSubject (next)",
+			subject:  event.NewBehaviorSubject(0),
+			values:   []int{1, 2},
+			expected: []int{2},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			for _, a := range tC.values {
+				tC.subject.Next(a)
+			}
+			tC.subject.Error(errFailed)
+			tC.subject.Next(42)
+
+			assert.Equal(t, tC.expected, collect(tC.subject.Event()))
+			assert.Equal(t, errFailed, tC.subject.Err())
+		})
+	}
+}
+
+func TestSubjectConcurrent(t *testing.T) {
+	s := event.NewReplaySubject[int](100)
+
+	var (
+		wg        sync.WaitGroup
+		collected = make([][]int, 10)
+	)
+
+	for i := range collected {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			collected[i] = collect(s.Event())
+		}(i)
+	}
+
+	for i := 0; i < 100; i++ {
+		s.Next(i)
+	}
+
+	s.Complete()
+
+	wg.Wait()
+
+	for _, as := range collected {
+		assert.Len(t, as, 100)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		event    func(s *warptest.Scheduler) warp.Event[int]
+		expected string
+	}{
+		{
+			desc: "Timeout",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.Timeout(warptest.Cold(s, "-a-----b|", values), s.Frames(3))
+			},
+			expected: "-a--|",
+		},
+		{
+			desc: "Timeout (in time)",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.Timeout(warptest.Cold(s, "-a-b|", values), s.Frames(3))
+			},
+			expected: "-a-b|",
+		},
+		{
+			desc: "TimeoutFirst",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.TimeoutFirst(warptest.Cold(s, "----a-b|", values), s.Frames(2))
+			},
+			expected: "--|",
+		},
+		{
+			desc: "TimeoutFirst (in time)",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.TimeoutFirst(warptest.Cold(s, "-a-----b|", values), s.Frames(3))
+			},
+			expected: "-a-----b|",
+		},
+		{
+			desc: "TimeoutWith",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.TimeoutWith(warptest.Cold(s, "-a-----b|", values), s.Frames(3), warptest.Cold(s, "-c|", values))
+			},
+			expected: "-a---c|",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := warptest.NewScheduler()
+			warptest.Expect(t, s, tC.event(s), tC.expected, values)
+		})
+	}
+}
+
+func TestRate(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		event    func(s *warptest.Scheduler) warp.Event[int]
+		expected string
+	}{
+		{
+			desc: "Debounce",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.Debounce(warptest.Cold(s, "-ab-----cd|", values), s.Frames(3))
+			},
+			expected: "-----b----(d|)",
+		},
+		{
+			desc: "Throttle (leading)",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.Throttle(warptest.Cold(s, "-ab-----cd|", values), s.Frames(3), event.ThrottleLeading)
+			},
+			expected: "-a------c-|",
+		},
+		{
+			desc: "Throttle (trailing)",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.Throttle(warptest.Cold(s, "-ab-----cd|", values), s.Frames(3), event.ThrottleTrailing)
+			},
+			expected: "----b-----(d|)",
+		},
+		{
+			desc: "Throttle (leading and trailing)",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.Throttle(warptest.Cold(s, "-ab-----cd|", values), s.Frames(3), event.ThrottleLeading|event.ThrottleTrailing)
+			},
+			expected: "-a--b---c-(d|)",
+		},
+		{
+			desc: "Audit",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.Audit(warptest.Cold(s, "-ab-----cde--|", values), s.Frames(3))
+			},
+			expected: "----b------e-|",
+		},
+		{
+			desc: "SampleEvery",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.SampleEvery(warptest.Cold(s, "-ab----c-d-|", values), s.Frames(4))
+			},
+			expected: "----b---c--|",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := warptest.NewScheduler()
+			warptest.Expect(t, s, tC.event(s), tC.expected, values)
+		})
+	}
+}
+
+func TestZip(t *testing.T) {
+	nums := map[rune]int{
+		'a': 1, 'b': 2, 'j': 10, 'k': 20, 'u': 100, 'v': 200,
+		'p': 11, 'q': 22, 's': 111, 't': 222,
+	}
+
+	testCases := []struct {
+		desc     string
+		event    func(s *warptest.Scheduler) warp.Event[int]
+		expected string
+	}{
+		{
+			desc: "Zip",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.Map(event.Zip(
+					warptest.Cold(s, "-ab----|", nums),
+					warptest.Cold(s, "---j-k-|", nums),
+				), sum)
+			},
+			expected: "---p-q-|",
+		},
+		{
+			desc: "Zip ends with an empty source",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.Map(event.Zip(
+					warptest.Cold(s, "------a|", nums),
+					warptest.Cold(s, "-|", nums),
+				), sum)
+			},
+			expected: "-|",
+		},
+		{
+			desc: "Zip waits for buffered values",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.Map(event.Zip(
+					warptest.Cold(s, "-ab|", nums),
+					warptest.Cold(s, "----j--k--|", nums),
+				), sum)
+			},
+			expected: "----p--(q|)",
+		},
+		{
+			desc: "Zip3",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.Map(event.Zip3(
+					warptest.Cold(s, "-a---b|", nums),
+					warptest.Cold(s, "--jk|", nums),
+					warptest.Cold(s, "---u-v---|", nums),
+				), sum3)
+			},
+			expected: "---s-(t|)",
+		},
+		{
+			desc: "Zip3 ends with an empty source",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.Map(event.Zip3(
+					warptest.Cold(s, "----a|", nums),
+					warptest.Cold(s, "--|", nums),
+					warptest.Cold(s, "---u|", nums),
+				), sum3)
+			},
+			expected: "--|",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := warptest.NewScheduler()
+			warptest.Expect(t, s, tC.event(s), tC.expected, nums)
+		})
+	}
+}
+
+func TestCombineLatest(t *testing.T) {
+	nums := map[rune]int{
+		'a': 1, 'b': 2, 'j': 10, 'k': 20, 'u': 100, 'v': 200,
+		'p': 11, 'q': 12, 'r': 22, 's': 111, 't': 211, 'w': 212,
+	}
+
+	testCases := []struct {
+		desc     string
+		event    func(s *warptest.Scheduler) warp.Event[int]
+		expected string
+	}{
+		{
+			desc: "CombineLatest",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.Map(event.CombineLatest(
+					warptest.Cold(s, "-a---b--|", nums),
+					warptest.Cold(s, "---j---k|", nums),
+				), sum)
+			},
+			expected: "---p-q-r|",
+		},
+		{
+			desc: "CombineLatest3",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.Map(event.CombineLatest3(
+					warptest.Cold(s, "-a-----b|", nums),
+					warptest.Cold(s, "--j|", nums),
+					warptest.Cold(s, "---u-v--|", nums),
+				), sum3)
+			},
+			expected: "---s-t-w|",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := warptest.NewScheduler()
+			warptest.Expect(t, s, tC.event(s), tC.expected, nums)
+		})
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		event    func(s *warptest.Scheduler) warp.Event[int]
+		expected string
+	}{
+		{
+			desc: "SwitchMap",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.SwitchMap(warptest.Cold(s, "-a--b----|", values), inner(s, "-d---d|", "-e-e|"))
+			},
+			expected: "--d--e-e-|",
+		},
+		{
+			desc: "ExhaustMap",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.ExhaustMap(warptest.Cold(s, "-ab---c|", values), inner(s, "-d-d|", "-e|", "-e|"))
+			},
+			expected: "--d-d--e|",
+		},
+		{
+			desc: "MergeMap",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.MergeMap(warptest.Cold(s, "(abc)|", values), inner(s, "-----a|", "---b|", "-c|"), 0)
+			},
+			expected: "-c-b-a|",
+		},
+		{
+			desc: "MergeMap (concurrency)",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.MergeMap(warptest.Cold(s, "(abc)|", values), inner(s, "------a|", "---b|", "-c|"), 2)
+			},
+			expected: "---b-ca|",
+		},
+		{
+			desc: "ConcatMap",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.ConcatMap(warptest.Cold(s, "(abc)|", values), inner(s, "------a|", "---b|", "-c|"))
+			},
+			expected: "------a---b-c|",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := warptest.NewScheduler()
+			warptest.Expect(t, s, tC.event(s), tC.expected, values)
+		})
+	}
+}
+
+// inner creates a function which maps n to a cold event described by the nth
+// marble, counting from 1.
+func inner(s *warptest.Scheduler, marbles ...string) func(int) warp.Event[int] {
+	return func(n int) warp.Event[int] {
+		return warptest.Cold(s, marbles[n-1], values)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		event    func(s *warptest.Scheduler) warp.Event[int]
+		expected string
+	}{
+		{
+			desc: "RateLimitDrop",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				l := limiter.NewTokenBucket(s.Frames(3), 1)
+				return event.RateLimit(warptest.Cold(s, "-ab--c|", values), l, event.RateLimitDrop)
+			},
+			expected: "-a---c|",
+		},
+		{
+			desc: "RateLimitDelay",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				l := limiter.NewLeakyBucket(s.Frames(2), 10)
+				return event.RateLimit(warptest.Cold(s, "-(abc)|", values), l, event.RateLimitDelay)
+			},
+			expected: "-a-b-c|",
+		},
+		{
+			desc: "RateLimitDelay (rejected)",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.RateLimit(warptest.Cold(s, "-a-b-c|", values), &rejectSecond{}, event.RateLimitDelay)
+			},
+			expected: "-a---c|",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := warptest.NewScheduler()
+			warptest.Expect(t, s, tC.event(s), tC.expected, values)
+		})
+	}
+}
+
+// rejectSecond is a limiter which rejects the second operation it is asked for.
+type rejectSecond struct {
+	n int32
+}
+
+func (l *rejectSecond) Acquire(context.Context) (func(), error) {
+	if atomic.AddInt32(&l.n, 1) == 2 {
+		return nil, limiter.ErrLimited
+	}
+	return func() {}, nil
+}
+
+func (l *rejectSecond) TryAcquire(ctx context.Context) (func(), bool) {
+	release, err := l.Acquire(ctx)
+	return release, err == nil
+}
+
+func TestShare(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		event    func(s *warptest.Scheduler, fa warp.Event[int]) warp.Event[int]
+		source   string
+		expected string
+	}{
+		{
+			desc: "Share",
+			event: func(s *warptest.Scheduler, fa warp.Event[int]) warp.Event[int] {
+				shared := event.Share(fa)
+				return event.Alt(shared, shared)
+			},
+			source:   "-a----b----|",
+			expected: "-(aa)-(bb)-|",
+		},
+		{
+			desc: "Publish",
+			event: func(s *warptest.Scheduler, fa warp.Event[int]) warp.Event[int] {
+				p := event.Publish(fa)
+				p.Connect(s.Context(context.Background()))
+				return p.Event()
+			},
+			source:   "-a-b-|",
+			expected: "-a-b-|",
+		},
+		{
+			desc: "ReplayLast",
+			event: func(s *warptest.Scheduler, fa warp.Event[int]) warp.Event[int] {
+				shared := event.ReplayLast(fa, 1)
+				return event.Alt(shared, event.Chain(event.After(s.Frames(5), 0), func(int) warp.Event[int] {
+					return shared
+				}))
+			},
+			source:   "-a-b-------(c|)",
+			expected: "-a-b-b-----(cc|)",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var (
+				s             = warptest.NewScheduler()
+				subscriptions int32
+				cold          = warptest.Cold(s, tC.source, values)
+			)
+
+			source := func(ctx context.Context, sub chan<- int) {
+				atomic.AddInt32(&subscriptions, 1)
+				cold(ctx, sub)
+			}
+
+			warptest.Expect(t, s, tC.event(s, source), tC.expected, values)
+
+			assert.Equal(t, int32(1), atomic.LoadInt32(&subscriptions))
+		})
+	}
+}
+
+func TestShareClock(t *testing.T) {
+	s := warptest.NewScheduler()
+
+	shared := event.Share(event.Interval(s.Frames(2)))
+
+	warptest.Expect(t, s, event.Take(event.Count(shared), 3), "--a-b-(c|)", values)
+}
+
+func TestShareReconnect(t *testing.T) {
+	var (
+		shared = event.Share(event.From([]int{1, 2}))
+		first  = make(chan int)
+	)
+
+	// The first subscriber holds on to its subscription without receiving the
+	// last value, while the source ends.
+	go shared(context.TODO(), first)
+
+	assert.Equal(t, 1, <-first)
+
+	// Subscribers which arrive before the source has been disconnected may
+	// receive the last value or nothing at all, the others must reconnect.
+	reconnected := false
+
+	for i := 0; i < 10 && !reconnected; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+		ns := collectContext(ctx, shared)
+		timedOut := ctx.Err() != nil
+		cancel()
+
+		assert.False(t, timedOut, "attempt %d", i)
+
+		if timedOut || len(ns) == 2 {
+			assert.Equal(t, []int{1, 2}, ns)
+			reconnected = true
+		}
+	}
+
+	assert.True(t, reconnected)
+	assert.Equal(t, 2, <-first)
+}
+
+func TestUsing(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		acquire  warp.Result[[]int]
+		take     int
+		expected []int
+		released int32
+	}{
+		{
+			desc:     "completes",
+			acquire:  func(context.Context) ([]int, error) { return []int{1, 2, 3}, nil },
+			take:     -1,
+			expected: []int{1, 2, 3},
+			released: 1,
+		},
+		{
+			desc:     "cancelled",
+			acquire:  func(context.Context) ([]int, error) { return []int{1, 2, 3}, nil },
+			take:     1,
+			expected: []int{1},
+			released: 1,
+		},
+		{
+			desc:    "acquire fails",
+			acquire: func(context.Context) ([]int, error) { return nil, errFailed },
+			take:    -1,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var released int32
+
+			fa := event.Using(tC.acquire, event.From[int], func([]int) error {
+				atomic.AddInt32(&released, 1)
+				return errFailed
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var (
+				as        = make(chan int)
+				collected []int
+			)
+
+			go fa(ctx, as)
+
+			for a := range as {
+				collected = append(collected, a)
+				if len(collected) == tC.take {
+					cancel()
+				}
+			}
+
+			assert.Equal(t, tC.expected, collected)
+			assert.Equal(t, tC.released, atomic.LoadInt32(&released))
+		})
+	}
+}
+
+func TestRecover(t *testing.T) {
+	var recovered *result.PanicError
+
+	fa := event.Recover(event.Map(event.From([]int{1, 2, 0, 4}), func(n int) int {
+		return 4 / n
+	}), func(err *result.PanicError) {
+		recovered = err
+	})
+
+	assert.Equal(t, []int{4, 2}, collect(fa))
+	assert.EqualError(t, recovered, "panic: runtime error: integer divide by zero")
+	assert.Contains(t, string(recovered.Stack), "event_test.go")
+
+	recovered = nil
+
+	assert.Equal(t, []int{1, 2}, collect(event.Recover(event.From([]int{1, 2}), func(err *result.PanicError) {
+		recovered = err
+	})))
+	assert.Nil(t, recovered)
+}
+
+func TestAll(t *testing.T) {
+	var ns []int
+
+	for n := range event.All(context.TODO(), event.From([]int{1, 2, 3})) {
+		ns = append(ns, n)
+	}
+
+	assert.Equal(t, []int{1, 2, 3}, ns)
+
+	stopped := false
+
+	naturals := func(yield func(int) bool) {
+		defer func() {
+			stopped = true
+		}()
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	ns = nil
+
+	for n := range event.All(context.TODO(), event.FromSeq(naturals)) {
+		if n == 3 {
+			break
+		}
+		ns = append(ns, n)
+	}
+
+	assert.Equal(t, []int{0, 1, 2}, ns)
+	assert.True(t, stopped)
+}
+
+func TestFromSeq(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 3}, collect(event.FromSeq(slices.Values([]int{1, 2, 3}))))
+
+	assert.Equal(t, []warp.Tuple[int, string]{
+		{First: 0, Second: "a"},
+		{First: 1, Second: "b"},
+	}, collect(event.FromSeq2(slices.All([]string{"a", "b"}))))
+}
+
+func BenchmarkPipeline(b *testing.B) {
+	ns := make([]int, 1000)
+	for i := range ns {
+		ns[i] = i - 500
+	}
+
+	b.Run("stages", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			event.Reduce(context.TODO(), event.Map(event.Filter(event.Map(event.From(ns), double), isPositive), double), 0, add)
+		}
+	})
+
+	b.Run("buffered", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			event.Reduce(context.TODO(), event.MapBuffered(event.FilterBuffered(event.MapBuffered(event.From(ns), double, 64), isPositive, 64), double, 64), 0, add)
+		}
+	})
+
+	b.Run("fused", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			event.Reduce(context.TODO(), event.Fuse(event.From(ns), doubleFilterDouble()), 0, add)
+		}
+	})
+
+	b.Run("fused and buffered", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			event.Reduce(context.TODO(), event.FuseBuffered(event.From(ns), doubleFilterDouble(), 64), 0, add)
+		}
+	})
+}
+
 func assertEq(t *testing.T, dequeue warp.Event[int], expected []int, unordered bool) {
 	r := make(chan int)
 
@@ -133,6 +1029,56 @@ func assertEq(t *testing.T, dequeue warp.Event[int], expected []int, unordered b
 	assert.Equal(t, expected, collected)
 }
 
+// delayed creates an event which emits each value after waiting for the
+// corresponding number of milliseconds since the previous one. A trailing
+// delay, if any, is waited before the event ends.
+func delayed(as []int, ms []int) warp.Event[int] {
+	return func(ctx context.Context, sub chan<- int) {
+		defer close(sub)
+
+		for i, d := range ms {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Millisecond * time.Duration(d)):
+			}
+			if i == len(as) {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case sub <- as[i]:
+			}
+		}
+	}
+}
+
+var (
+	errFailed = errors.New("failed")
+	values    = map[rune]int{'a': 1, 'b': 2, 'c': 3, 'd': 4, 'e': 5}
+)
+
+func collectContext[A any](ctx context.Context, fa warp.Event[A]) []A {
+	return event.Reduce(ctx, fa, nil, func(as []A, a A) []A {
+		return append(as, a)
+	})
+}
+
+func collect[A any](fa warp.Event[A]) []A {
+	return event.Reduce(context.TODO(), fa, nil, func(as []A, a A) []A {
+		return append(as, a)
+	})
+}
+
+func sum(t warp.Tuple[int, int]) int {
+	return t.First + t.Second
+}
+
+func sum3(t warp.Tuple3[int, int, int]) int {
+	return t.First + t.Second + t.Third
+}
+
 func double(n int) int {
 	return n * 2
 }
@@ -159,3 +1105,7 @@ func doublePositive(n int) warp.Nilable[int] {
 	}
 	return nilable.Some(n * 2)
 }
+
+func doubleFilterDouble() event.Op[int, int] {
+	return event.ComposeOp(event.MapOp(double), event.ComposeOp(event.FilterOp(isPositive), event.MapOp(double)))
+}
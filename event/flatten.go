@@ -0,0 +1,195 @@
+package event
+
+import (
+	"context"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/internal/flow"
+)
+
+// SwitchMap creates an event which maps each value received from a source event
+// to an inner event, emitting values only from the most recent inner event.
+// The context of the previous inner event is cancelled whenever a new one
+// starts. It ends when the source event and the last inner event have ended.
+func SwitchMap[A, B any](fa warp.Event[A], f func(A) warp.Event[B]) warp.Event[B] {
+	return func(ctx context.Context, sub chan<- B) {
+		defer close(sub)
+
+		ctx, cancel := flow.WithCancel(ctx)
+		defer cancel()
+
+		var (
+			as          = make(chan A)
+			bs          chan B
+			done        = ctx.Done()
+			a           A
+			b           B
+			ok          bool
+			cancelInner = context.CancelFunc(func() {})
+		)
+
+		defer func() {
+			cancelInner()
+		}()
+
+		go fa(ctx, as)
+
+		for as != nil || bs != nil {
+			select {
+			case <-done:
+				return
+			case a, ok = <-as:
+				if !ok {
+					as = nil
+					continue
+				}
+				cancelInner()
+				bs, cancelInner = subscribe(ctx, f(a))
+			case b, ok = <-bs:
+				if !ok {
+					bs = nil
+					continue
+				}
+				if !flow.Emit(done, sub, b) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ExhaustMap creates an event which maps each value received from a source event
+// to an inner event, ignoring source values for as long as the current inner event
+// is still active. It ends when the source event and the last inner event have
+// ended.
+func ExhaustMap[A, B any](fa warp.Event[A], f func(A) warp.Event[B]) warp.Event[B] {
+	return func(ctx context.Context, sub chan<- B) {
+		defer close(sub)
+
+		ctx, cancel := flow.WithCancel(ctx)
+		defer cancel()
+
+		var (
+			as   = make(chan A)
+			bs   chan B
+			done = ctx.Done()
+			a    A
+			b    B
+			ok   bool
+		)
+
+		go fa(ctx, as)
+
+		for as != nil || bs != nil {
+			select {
+			case <-done:
+				return
+			case a, ok = <-as:
+				if !ok {
+					as = nil
+					continue
+				}
+				if bs != nil {
+					continue
+				}
+				bs = make(chan B)
+
+				go f(a)(ctx, bs)
+			case b, ok = <-bs:
+				if !ok {
+					bs = nil
+					continue
+				}
+				if !flow.Emit(done, sub, b) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// MergeMap creates an event which maps each value received from a source event
+// to an inner event, emitting values from all of the inner events as they occur.
+// At most concurrency inner events are active at a time, a limit which is not
+// positive means no limit; a limit of 1 makes it behave like Chain. It ends when
+// the source event and all of the inner events have ended.
+func MergeMap[A, B any](fa warp.Event[A], f func(A) warp.Event[B], concurrency int) warp.Event[B] {
+	return func(ctx context.Context, sub chan<- B) {
+		defer close(sub)
+
+		ctx, cancel := flow.WithCancel(ctx)
+		defer cancel()
+
+		var (
+			as       = make(chan A)
+			reads    = as
+			merged   = make(chan B)
+			finished = make(chan struct{})
+			done     = ctx.Done()
+			a        A
+			b        B
+			ok       bool
+			active   = 0
+		)
+
+		go fa(ctx, as)
+
+		for reads != nil || active > 0 {
+			select {
+			case <-done:
+				return
+			case a, ok = <-reads:
+				if !ok {
+					as, reads = nil, nil
+					continue
+				}
+
+				active += 1
+
+				go func(fb warp.Event[B]) {
+					bs := make(chan B)
+
+					go fb(ctx, bs)
+
+					for b := range bs {
+						if !flow.Emit(done, merged, b) {
+							return
+						}
+					}
+
+					select {
+					case <-done:
+					case finished <- empty:
+					}
+				}(f(a))
+
+				if concurrency > 0 && active >= concurrency {
+					reads = nil
+				}
+			case b = <-merged:
+				if !flow.Emit(done, sub, b) {
+					return
+				}
+			case <-finished:
+				active -= 1
+				reads = as
+			}
+		}
+	}
+}
+
+// subscribe runs an event in a new goroutine with a context derived from ctx,
+// returning the channel it emits to and a function which cancels it.
+func subscribe[A any](ctx context.Context, fa warp.Event[A]) (chan A, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	as := make(chan A)
+	go fa(ctx, as)
+	return as, cancel
+}
+
+// ConcatMap creates an event which maps each value received from a source event
+// to an inner event, emitting the values of the inner events one inner event after
+// another. It is MergeMap with a concurrency of 1.
+func ConcatMap[A, B any](fa warp.Event[A], f func(A) warp.Event[B]) warp.Event[B] {
+	return MergeMap(fa, f, 1)
+}
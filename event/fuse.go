@@ -0,0 +1,91 @@
+package event
+
+import (
+	"context"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/internal/flow"
+	"github.com/onur1/warp/nilable"
+)
+
+// An Op is a stateless operator which turns a value into another one, reporting
+// whether it should be emitted at all. Adjacent operators are composed with
+// ComposeOp and run by Fuse in a single goroutine, instead of a goroutine and a
+// channel handoff per operator as with Map, Filter and FilterMap.
+type Op[A, B any] func(A) (B, bool)
+
+// MapOp creates an operator which applies a function on each value, like Map.
+func MapOp[A, B any](f func(A) B) Op[A, B] {
+	return func(a A) (B, bool) {
+		return f(a), true
+	}
+}
+
+// FilterOp creates an operator which keeps the values for which a predicate holds,
+// like Filter.
+func FilterOp[A any](predicate warp.Predicate[A]) Op[A, A] {
+	return func(a A) (A, bool) {
+		return a, predicate(a)
+	}
+}
+
+// FilterMapOp creates an operator which applies a function on each value, keeping
+// the values which are not nil, like FilterMap.
+func FilterMapOp[A, B any](f func(A) warp.Nilable[B]) Op[A, B] {
+	return func(a A) (b B, ok bool) {
+		if nb := f(a); nilable.IsSome(nb) {
+			return *nb, true
+		}
+		return
+	}
+}
+
+// ComposeOp creates an operator which applies two operators one after the other,
+// skipping the second one for the values dropped by the first one.
+func ComposeOp[A, B, C any](f Op[A, B], g Op[B, C]) Op[A, C] {
+	return func(a A) (c C, ok bool) {
+		b, ok := f(a)
+		if !ok {
+			return
+		}
+		return g(b)
+	}
+}
+
+// Fuse creates an event by applying an operator on each value received from a
+// source event, emitting the values it keeps. For example,
+// Fuse(fa, ComposeOp(MapOp(f), ComposeOp(FilterOp(p), MapOp(g)))) emits the same
+// values as Map(Filter(Map(fa, f), p), g) with one goroutine instead of three.
+func Fuse[A, B any](fa warp.Event[A], op Op[A, B]) warp.Event[B] {
+	return FuseBuffered(fa, op, 0)
+}
+
+// FuseBuffered is like Fuse but it receives the values of the source event
+// through a channel which buffers up to n values, letting the source event run
+// ahead while the operator or the subscriber is busy.
+func FuseBuffered[A, B any](fa warp.Event[A], op Op[A, B], n int) warp.Event[B] {
+	return func(ctx context.Context, sub chan<- B) {
+		defer close(sub)
+
+		var (
+			as = make(chan A, max(n, 0))
+			a  A
+		)
+
+		var done <-chan struct{}
+
+		if ctx != nil {
+			done = ctx.Done()
+		}
+
+		go fa(ctx, as)
+
+		for a = range as {
+			if b, ok := op(a); ok {
+				if !flow.Emit(done, sub, b) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package event
+
+import (
+	"context"
+	"iter"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/internal/flow"
+)
+
+// All returns an iterator over the values of an event, subscribing to it with a
+// context derived from ctx when the iteration starts. Breaking out of the loop
+// cancels the event, and the iterator waits for it to end before returning.
+func All[A any](ctx context.Context, fa warp.Event[A]) iter.Seq[A] {
+	return func(yield func(A) bool) {
+		ctx, cancel := flow.WithCancel(ctx)
+
+		as := make(chan A)
+
+		go fa(ctx, as)
+
+		defer func() {
+			cancel()
+			for range as {
+			}
+		}()
+
+		for a := range as {
+			if !yield(a) {
+				return
+			}
+		}
+	}
+}
+
+// FromSeq creates an event which emits the values of an iterator, stopping the
+// iteration when the context is cancelled.
+func FromSeq[A any](seq iter.Seq[A]) warp.Event[A] {
+	return func(ctx context.Context, sub chan<- A) {
+		defer close(sub)
+
+		var done <-chan struct{}
+
+		if ctx != nil {
+			done = ctx.Done()
+		}
+
+		for a := range seq {
+			if !flow.Emit(done, sub, a) {
+				return
+			}
+		}
+	}
+}
+
+// FromSeq2 is like FromSeq but it emits the pairs of values of an iterator as
+// tuples.
+func FromSeq2[A, B any](seq iter.Seq2[A, B]) warp.Event[warp.Tuple[A, B]] {
+	return func(ctx context.Context, sub chan<- warp.Tuple[A, B]) {
+		defer close(sub)
+
+		var done <-chan struct{}
+
+		if ctx != nil {
+			done = ctx.Done()
+		}
+
+		for a, b := range seq {
+			if !flow.Emit(done, sub, warp.Tuple[A, B]{First: a, Second: b}) {
+				return
+			}
+		}
+	}
+}
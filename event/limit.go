@@ -0,0 +1,65 @@
+package event
+
+import (
+	"context"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/internal/flow"
+	"github.com/onur1/warp/limiter"
+)
+
+// A RateLimitPolicy specifies what happens to a value which a limiter doesn't let
+// through right away.
+type RateLimitPolicy int
+
+const (
+	// RateLimitDelay holds a value back until the limiter lets it through.
+	RateLimitDelay RateLimitPolicy = iota
+	// RateLimitDrop drops a value unless the limiter lets it through right away.
+	RateLimitDrop
+)
+
+// RateLimit creates an event which emits values from a source event as a limiter
+// lets them through, either delaying or dropping the values which are not let
+// through right away depending on the policy. What has been acquired from the
+// limiter is released once a value has been emitted. A delayed value which the
+// limiter rejects is dropped.
+func RateLimit[A any](fa warp.Event[A], l limiter.Limiter, policy RateLimitPolicy) warp.Event[A] {
+	return func(ctx context.Context, sub chan<- A) {
+		defer close(sub)
+
+		ctx, cancel := flow.WithCancel(ctx)
+		defer cancel()
+
+		var (
+			as      = make(chan A)
+			done    = ctx.Done()
+			release func()
+			err     error
+			ok      bool
+		)
+
+		go fa(ctx, as)
+
+		for a := range as {
+			if policy == RateLimitDrop {
+				if release, ok = l.TryAcquire(ctx); !ok {
+					continue
+				}
+			} else if release, err = l.Acquire(ctx); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				continue
+			}
+
+			ok = flow.Emit(done, sub, a)
+
+			release()
+
+			if !ok {
+				return
+			}
+		}
+	}
+}
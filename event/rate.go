@@ -0,0 +1,277 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/clock"
+	"github.com/onur1/warp/internal/flow"
+)
+
+// A ThrottleMode specifies which values of a throttling window are emitted.
+type ThrottleMode int
+
+const (
+	// ThrottleLeading emits the first value of a window as soon as it arrives.
+	ThrottleLeading ThrottleMode = 1 << iota
+	// ThrottleTrailing emits the latest value of a window when it ends.
+	ThrottleTrailing
+)
+
+// A delay is a one-shot timer whose channel is nil while it is not armed, so that
+// it can be used directly in a select statement.
+type delay struct {
+	clock clock.Clock
+	timer clock.Timer
+	C     <-chan time.Time
+}
+
+func newDelay(ctx context.Context) delay {
+	return delay{clock: clock.FromContext(ctx)}
+}
+
+// Reset stops the timer if it is armed and arms it again for the given duration.
+func (d *delay) Reset(dur time.Duration) {
+	d.Stop()
+	d.timer = d.clock.NewTimer(dur)
+	d.C = d.timer.C()
+}
+
+// Stop disarms the timer.
+func (d *delay) Stop() {
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	d.C = nil
+}
+
+// Debounce creates an event which emits a value from a source event only after
+// the specified duration has passed without the source emitting another value.
+// A pending value is emitted when the source event ends.
+func Debounce[A any](fa warp.Event[A], dur time.Duration) warp.Event[A] {
+	return func(ctx context.Context, sub chan<- A) {
+		defer close(sub)
+
+		var (
+			as      = make(chan A)
+			d       = newDelay(ctx)
+			a       A
+			latest  A
+			pending bool
+			ok      bool
+		)
+
+		var done <-chan struct{}
+
+		if ctx != nil {
+			done = ctx.Done()
+		}
+
+		defer d.Stop()
+
+		go fa(ctx, as)
+
+		for {
+			select {
+			case <-done:
+				return
+			case a, ok = <-as:
+				if !ok {
+					if pending {
+						flow.Emit(done, sub, latest)
+					}
+					return
+				}
+				latest, pending = a, true
+				d.Reset(dur)
+			case <-d.C:
+				d.Stop()
+				pending = false
+				if !flow.Emit(done, sub, latest) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Throttle creates an event which emits at most one value from a source event per
+// window of the specified duration. The mode controls whether the first value of
+// a window, the latest value of a window, or both are emitted; a zero mode is
+// treated as ThrottleLeading. A new window starts after each trailing emission,
+// and a pending trailing value is emitted when the source event ends.
+func Throttle[A any](fa warp.Event[A], dur time.Duration, mode ThrottleMode) warp.Event[A] {
+	if mode&(ThrottleLeading|ThrottleTrailing) == 0 {
+		mode = ThrottleLeading
+	}
+
+	var (
+		leading  = mode&ThrottleLeading != 0
+		trailing = mode&ThrottleTrailing != 0
+	)
+
+	return func(ctx context.Context, sub chan<- A) {
+		defer close(sub)
+
+		var (
+			as      = make(chan A)
+			d       = newDelay(ctx)
+			a       A
+			latest  A
+			pending bool
+			ok      bool
+		)
+
+		var done <-chan struct{}
+
+		if ctx != nil {
+			done = ctx.Done()
+		}
+
+		defer d.Stop()
+
+		go fa(ctx, as)
+
+		for {
+			select {
+			case <-done:
+				return
+			case a, ok = <-as:
+				if !ok {
+					if pending {
+						flow.Emit(done, sub, latest)
+					}
+					return
+				}
+				if d.C != nil {
+					if trailing {
+						latest, pending = a, true
+					}
+					break
+				}
+				if leading {
+					if !flow.Emit(done, sub, a) {
+						return
+					}
+				} else {
+					latest, pending = a, true
+				}
+				d.Reset(dur)
+			case <-d.C:
+				d.Stop()
+				if !pending {
+					break
+				}
+				pending = false
+				if !flow.Emit(done, sub, latest) {
+					return
+				}
+				d.Reset(dur)
+			}
+		}
+	}
+}
+
+// Audit creates an event which, after receiving a value from a source event,
+// waits for the specified duration and then emits the latest value received
+// from the source in the meantime. A pending value is emitted when the source
+// event ends.
+func Audit[A any](fa warp.Event[A], dur time.Duration) warp.Event[A] {
+	return func(ctx context.Context, sub chan<- A) {
+		defer close(sub)
+
+		var (
+			as      = make(chan A)
+			d       = newDelay(ctx)
+			a       A
+			latest  A
+			pending bool
+			ok      bool
+		)
+
+		var done <-chan struct{}
+
+		if ctx != nil {
+			done = ctx.Done()
+		}
+
+		defer d.Stop()
+
+		go fa(ctx, as)
+
+		for {
+			select {
+			case <-done:
+				return
+			case a, ok = <-as:
+				if !ok {
+					if pending {
+						flow.Emit(done, sub, latest)
+					}
+					return
+				}
+				latest, pending = a, true
+				if d.C == nil {
+					d.Reset(dur)
+				}
+			case <-d.C:
+				d.Stop()
+				pending = false
+				if !flow.Emit(done, sub, latest) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// SampleEvery creates an event which emits the latest value received from a source
+// event periodically, skipping periods in which the source has not emitted any
+// new value. A value received after the last period is dropped when the source
+// event ends.
+func SampleEvery[A any](fa warp.Event[A], dur time.Duration) warp.Event[A] {
+	return func(ctx context.Context, sub chan<- A) {
+		defer close(sub)
+
+		var (
+			as      = make(chan A)
+			ticker  = clock.FromContext(ctx).NewTicker(dur)
+			a       A
+			latest  A
+			pending bool
+			ok      bool
+		)
+
+		var done <-chan struct{}
+
+		if ctx != nil {
+			done = ctx.Done()
+		}
+
+		defer ticker.Stop()
+
+		go fa(ctx, as)
+
+		for {
+			select {
+			case <-done:
+				return
+			case a, ok = <-as:
+				if !ok {
+					return
+				}
+				latest, pending = a, true
+			case <-ticker.C():
+				if !pending {
+					break
+				}
+				pending = false
+				if !flow.Emit(done, sub, latest) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package event
+
+import (
+	"context"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/internal/flow"
+	"github.com/onur1/warp/result"
+)
+
+// Recover creates an event which emits the values of a source event, ending it
+// instead of crashing the process if the goroutine running the source event
+// panics, and reporting the panic to onPanic as a *result.PanicError. Functions
+// passed to an operator such as Map, Filter or Chain run in the goroutine of the
+// event created by that operator, so Recover should wrap the event which calls
+// the function that may panic.
+func Recover[A any](fa warp.Event[A], onPanic func(*result.PanicError)) warp.Event[A] {
+	return func(ctx context.Context, sub chan<- A) {
+		defer close(sub)
+
+		ctx, cancel := flow.WithCancel(ctx)
+		defer cancel()
+
+		var (
+			as     = make(chan A)
+			panics = make(chan *result.PanicError, 1)
+			done   = ctx.Done()
+			a      A
+			ok     bool
+		)
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					panics <- result.NewPanicError(r)
+				}
+				close(panics)
+			}()
+			fa(ctx, as)
+		}()
+
+		for {
+			select {
+			case <-done:
+				return
+			case a, ok = <-as:
+				if !ok {
+					as = nil
+					continue
+				}
+				if !flow.Emit(done, sub, a) {
+					return
+				}
+			case err, ok := <-panics:
+				if ok {
+					onPanic(err)
+				}
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package event
+
+import (
+	"context"
+	"sync"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/internal/flow"
+)
+
+// A Connectable is a hot event which multicasts the values of a source event to
+// all of its subscribers, once it is connected to the source.
+type Connectable[A any] struct {
+	source     warp.Event[A]
+	newSubject func() *Subject[A]
+	mu         sync.Mutex
+	subject    *Subject[A]
+	cancel     context.CancelFunc
+	refs       int
+}
+
+// Publish creates a connectable event which multicasts the values of a source
+// event. The source is only subscribed to when the connectable is connected.
+func Publish[A any](fa warp.Event[A]) *Connectable[A] {
+	return &Connectable[A]{source: fa, newSubject: NewSubject[A]}
+}
+
+// PublishReplay is like Publish but it replays the last n values of the source
+// event to new subscribers.
+func PublishReplay[A any](fa warp.Event[A], n int) *Connectable[A] {
+	return &Connectable[A]{source: fa, newSubject: func() *Subject[A] {
+		return NewReplaySubject[A](n)
+	}}
+}
+
+// Share creates an event which multicasts the values of a source event to all of
+// its subscribers, subscribing to the source with the first subscriber and
+// cancelling it when the last one leaves.
+func Share[A any](fa warp.Event[A]) warp.Event[A] {
+	return Publish(fa).RefCount()
+}
+
+// ReplayLast is like Share but it replays the last n values of the source event
+// to new subscribers for as long as the source is subscribed.
+func ReplayLast[A any](fa warp.Event[A], n int) warp.Event[A] {
+	return PublishReplay(fa, n).RefCount()
+}
+
+// Event returns an event which emits the values of the source event received
+// after subscription, for as long as the connectable stays connected.
+func (c *Connectable[A]) Event() warp.Event[A] {
+	return func(ctx context.Context, sub chan<- A) {
+		c.mu.Lock()
+		s := c.current()
+		r, replay := s.subscribe()
+		c.mu.Unlock()
+
+		s.serve(ctx, sub, r, replay)
+	}
+}
+
+// Connect subscribes to the source event unless it is already subscribed, and
+// returns a function which cancels the subscription.
+func (c *Connectable[A]) Connect(ctx context.Context) context.CancelFunc {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.connect(ctx)
+
+	return c.Disconnect
+}
+
+// Disconnect cancels the subscription to the source event, which ends the events
+// of the current subscribers.
+func (c *Connectable[A]) Disconnect() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.disconnect()
+}
+
+// RefCount returns an event which connects the connectable when it is subscribed
+// while not connected, and disconnects it when the last subscriber leaves. The
+// source is subscribed to with the values of the context of the subscriber which
+// connects it, such as its clock, but not with its cancellation.
+func (c *Connectable[A]) RefCount() warp.Event[A] {
+	return func(ctx context.Context, sub chan<- A) {
+		c.mu.Lock()
+		s := c.current()
+		r, replay := s.subscribe()
+		c.refs += 1
+		if c.cancel == nil {
+			c.connect(flow.Detach(ctx))
+		}
+		c.mu.Unlock()
+
+		defer func() {
+			c.mu.Lock()
+			c.refs -= 1
+			if c.refs == 0 {
+				c.disconnect()
+			}
+			c.mu.Unlock()
+		}()
+
+		s.serve(ctx, sub, r, replay)
+	}
+}
+
+// current returns the subject of the current connection, creating one if there
+// is none.
+func (c *Connectable[A]) current() *Subject[A] {
+	if c.subject == nil {
+		c.subject = c.newSubject()
+	}
+	return c.subject
+}
+
+func (c *Connectable[A]) connect(ctx context.Context) {
+	if c.cancel != nil {
+		return
+	}
+
+	ctx, cancel := flow.WithCancel(ctx)
+
+	var (
+		s  = c.current()
+		as = make(chan A)
+	)
+
+	c.cancel = cancel
+
+	go c.source(ctx, as)
+
+	go func() {
+		for a := range as {
+			s.Next(a)
+		}
+
+		s.Complete()
+
+		c.mu.Lock()
+		if c.subject == s {
+			c.subject, c.cancel = nil, nil
+		}
+		c.mu.Unlock()
+
+		cancel()
+	}()
+}
+
+func (c *Connectable[A]) disconnect() {
+	if c.cancel == nil {
+		return
+	}
+
+	c.cancel()
+
+	c.subject, c.cancel = nil, nil
+}
@@ -0,0 +1,196 @@
+package event
+
+import (
+	"context"
+	"sync"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/internal/flow"
+)
+
+// A Subject is a hot event source which multicasts the values pushed into it to
+// every subscriber of its event. A subject may replay the latest values to new
+// subscribers. It is safe for concurrent use.
+type Subject[A any] struct {
+	sending sync.Mutex
+	mu      sync.Mutex
+	subs    map[*subscriber[A]]struct{}
+	size    int
+	replay  []A
+	ended   chan struct{}
+	err     error
+}
+
+type subscriber[A any] struct {
+	in   chan A
+	quit chan struct{}
+}
+
+// NewSubject creates a subject which doesn't replay any values.
+func NewSubject[A any]() *Subject[A] {
+	return NewReplaySubject[A](0)
+}
+
+// NewReplaySubject creates a subject which replays the last n values to new
+// subscribers, even after it has ended.
+func NewReplaySubject[A any](n int) *Subject[A] {
+	return &Subject[A]{
+		subs:  make(map[*subscriber[A]]struct{}),
+		size:  n,
+		ended: make(chan struct{}),
+	}
+}
+
+// NewBehaviorSubject creates a subject which starts with an initial value and
+// replays the latest value to new subscribers.
+func NewBehaviorSubject[A any](a A) *Subject[A] {
+	s := NewReplaySubject[A](1)
+	s.replay = append(s.replay, a)
+	return s
+}
+
+// Next pushes a value to the subscribers, blocking until each of them has either
+// received it or cancelled its subscription. It is a no-op after the subject has
+// ended.
+func (s *Subject[A]) Next(a A) {
+	s.sending.Lock()
+	defer s.sending.Unlock()
+
+	s.mu.Lock()
+
+	if s.isEnded() {
+		s.mu.Unlock()
+		return
+	}
+
+	if s.size > 0 {
+		s.replay = append(s.replay, a)
+		if len(s.replay) > s.size {
+			s.replay = s.replay[len(s.replay)-s.size:]
+		}
+	}
+
+	subs := make([]*subscriber[A], 0, len(s.subs))
+	for r := range s.subs {
+		subs = append(subs, r)
+	}
+
+	s.mu.Unlock()
+
+	for _, r := range subs {
+		select {
+		case <-r.quit:
+		case r.in <- a:
+		}
+	}
+}
+
+// Complete ends the subject, which ends the event of every subscriber.
+func (s *Subject[A]) Complete() {
+	s.end(nil)
+}
+
+// Error ends the subject with an error, which ends the event of every subscriber
+// and is reported by Err.
+func (s *Subject[A]) Error(err error) {
+	s.end(err)
+}
+
+// Err returns the error which the subject has ended with, if any.
+func (s *Subject[A]) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Event returns an event which emits the values replayed by the subject followed
+// by the values pushed into it after subscription. It ends when the subject ends.
+func (s *Subject[A]) Event() warp.Event[A] {
+	return func(ctx context.Context, sub chan<- A) {
+		r, replay := s.subscribe()
+		s.serve(ctx, sub, r, replay)
+	}
+}
+
+func (s *Subject[A]) end(err error) {
+	s.sending.Lock()
+	defer s.sending.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.isEnded() {
+		return
+	}
+
+	s.err = err
+
+	close(s.ended)
+}
+
+func (s *Subject[A]) isEnded() bool {
+	select {
+	case <-s.ended:
+		return true
+	default:
+		return false
+	}
+}
+
+// subscribe registers a new subscriber along with a copy of the values to be
+// replayed to it, so that no value is missed before it starts being served.
+func (s *Subject[A]) subscribe() (*subscriber[A], []A) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := &subscriber[A]{
+		in:   make(chan A),
+		quit: make(chan struct{}),
+	}
+
+	if !s.isEnded() {
+		s.subs[r] = struct{}{}
+	}
+
+	return r, append([]A(nil), s.replay...)
+}
+
+func (s *Subject[A]) unsubscribe(r *subscriber[A]) {
+	s.mu.Lock()
+	delete(s.subs, r)
+	s.mu.Unlock()
+
+	close(r.quit)
+}
+
+func (s *Subject[A]) serve(ctx context.Context, sub chan<- A, r *subscriber[A], replay []A) {
+	defer close(sub)
+	defer s.unsubscribe(r)
+
+	var done <-chan struct{}
+
+	if ctx != nil {
+		done = ctx.Done()
+	}
+
+	for _, a := range replay {
+		if !flow.Emit(done, sub, a) {
+			return
+		}
+	}
+
+	var a A
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-s.ended:
+			return
+		case a = <-r.in:
+			if !flow.Emit(done, sub, a) {
+				return
+			}
+		}
+	}
+}
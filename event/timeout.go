@@ -0,0 +1,80 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/internal/flow"
+)
+
+// Timeout creates an event which emits values from a source event, ending if the
+// source doesn't emit a value within the specified duration, counting from the
+// subscription and from each value. The source is cancelled on timeout.
+func Timeout[A any](fa warp.Event[A], dur time.Duration) warp.Event[A] {
+	return timeout(fa, dur, false, nil)
+}
+
+// TimeoutFirst is like Timeout but it only limits the time until the first value
+// of the source event.
+func TimeoutFirst[A any](fa warp.Event[A], dur time.Duration) warp.Event[A] {
+	return timeout(fa, dur, true, nil)
+}
+
+// TimeoutWith is like Timeout but it switches to a fallback event on timeout.
+func TimeoutWith[A any](fa warp.Event[A], dur time.Duration, fallback warp.Event[A]) warp.Event[A] {
+	return timeout(fa, dur, false, fallback)
+}
+
+func timeout[A any](fa warp.Event[A], dur time.Duration, first bool, fallback warp.Event[A]) warp.Event[A] {
+	return func(ctx context.Context, sub chan<- A) {
+		parent := ctx
+
+		ctx, cancel := flow.WithCancel(ctx)
+		defer cancel()
+
+		var (
+			as   = make(chan A)
+			d    = newDelay(ctx)
+			done = ctx.Done()
+			a    A
+			ok   bool
+		)
+
+		defer d.Stop()
+
+		go fa(ctx, as)
+
+		d.Reset(dur)
+
+	LOOP:
+		for {
+			select {
+			case <-done:
+				break LOOP
+			case a, ok = <-as:
+				if !ok {
+					break LOOP
+				}
+				if first {
+					d.Stop()
+				} else {
+					d.Reset(dur)
+				}
+				if !flow.Emit(done, sub, a) {
+					break LOOP
+				}
+			case <-d.C:
+				d.Stop()
+				if fallback != nil {
+					cancel()
+					fallback(parent, sub)
+					return
+				}
+				break LOOP
+			}
+		}
+
+		close(sub)
+	}
+}
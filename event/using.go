@@ -0,0 +1,59 @@
+package event
+
+import (
+	"context"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/internal/flow"
+)
+
+// Using creates an event which acquires a resource, emits the values of the event
+// created from it, and then releases it. The resource is released exactly once
+// after the inner event has ended, whether it ends by itself, through the
+// cancellation of the context or because the subscriber stops early, and before
+// the event ends. Nothing is emitted if acquire fails; since an event can't fail,
+// the error of release is discarded.
+func Using[R, A any](acquire warp.Result[R], f func(R) warp.Event[A], release func(R) error) warp.Event[A] {
+	return func(ctx context.Context, sub chan<- A) {
+		defer close(sub)
+
+		r, err := acquire(ctx)
+		if err != nil {
+			return
+		}
+
+		defer func() {
+			_ = release(r)
+		}()
+
+		fa := f(r)
+
+		ctx, cancel := flow.WithCancel(ctx)
+
+		var (
+			as   = make(chan A)
+			done = ctx.Done()
+			a    A
+			ok   bool
+		)
+
+		go fa(ctx, as)
+
+		defer func() {
+			cancel()
+			for range as {
+			}
+		}()
+
+		for {
+			select {
+			case <-done:
+				return
+			case a, ok = <-as:
+				if !ok || !flow.Emit(done, sub, a) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package event
+
+import (
+	"context"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/internal/flow"
+)
+
+// Zip creates an event which pairs the values received from two source events
+// in order, emitting a tuple once both of them have emitted their next value.
+// Values which have no pair yet are buffered. It ends as soon as either of the
+// source events has ended and all of its values have been paired.
+func Zip[A, B any](fa warp.Event[A], fb warp.Event[B]) warp.Event[warp.Tuple[A, B]] {
+	return func(ctx context.Context, sub chan<- warp.Tuple[A, B]) {
+		defer close(sub)
+
+		ctx, cancel := flow.WithCancel(ctx)
+		defer cancel()
+
+		var (
+			as   = make(chan A)
+			bs   = make(chan B)
+			done = ctx.Done()
+			qa   []A
+			qb   []B
+		)
+
+		go fa(ctx, as)
+		go fb(ctx, bs)
+
+		for {
+			select {
+			case <-done:
+				return
+			case a, ok := <-as:
+				if !ok {
+					as = nil
+				} else {
+					qa = append(qa, a)
+				}
+			case b, ok := <-bs:
+				if !ok {
+					bs = nil
+				} else {
+					qb = append(qb, b)
+				}
+			}
+
+			for len(qa) > 0 && len(qb) > 0 {
+				t := warp.Tuple[A, B]{First: qa[0], Second: qb[0]}
+				qa, qb = qa[1:], qb[1:]
+				if !flow.Emit(done, sub, t) {
+					return
+				}
+			}
+
+			if (as == nil && len(qa) == 0) || (bs == nil && len(qb) == 0) {
+				return
+			}
+		}
+	}
+}
+
+// Zip3 is like Zip but it pairs the values received from three source events.
+func Zip3[A, B, C any](fa warp.Event[A], fb warp.Event[B], fc warp.Event[C]) warp.Event[warp.Tuple3[A, B, C]] {
+	return func(ctx context.Context, sub chan<- warp.Tuple3[A, B, C]) {
+		defer close(sub)
+
+		ctx, cancel := flow.WithCancel(ctx)
+		defer cancel()
+
+		var (
+			as   = make(chan A)
+			bs   = make(chan B)
+			cs   = make(chan C)
+			done = ctx.Done()
+			qa   []A
+			qb   []B
+			qc   []C
+		)
+
+		go fa(ctx, as)
+		go fb(ctx, bs)
+		go fc(ctx, cs)
+
+		for {
+			select {
+			case <-done:
+				return
+			case a, ok := <-as:
+				if !ok {
+					as = nil
+				} else {
+					qa = append(qa, a)
+				}
+			case b, ok := <-bs:
+				if !ok {
+					bs = nil
+				} else {
+					qb = append(qb, b)
+				}
+			case c, ok := <-cs:
+				if !ok {
+					cs = nil
+				} else {
+					qc = append(qc, c)
+				}
+			}
+
+			for len(qa) > 0 && len(qb) > 0 && len(qc) > 0 {
+				t := warp.Tuple3[A, B, C]{First: qa[0], Second: qb[0], Third: qc[0]}
+				qa, qb, qc = qa[1:], qb[1:], qc[1:]
+				if !flow.Emit(done, sub, t) {
+					return
+				}
+			}
+
+			if (as == nil && len(qa) == 0) || (bs == nil && len(qb) == 0) || (cs == nil && len(qc) == 0) {
+				return
+			}
+		}
+	}
+}
+
+// CombineLatest creates an event which emits a tuple of the latest values received
+// from two source events whenever either of them emits, once both of them have
+// emitted at least once. It ends when both source events end, or when one of
+// them ends without emitting any value.
+func CombineLatest[A, B any](fa warp.Event[A], fb warp.Event[B]) warp.Event[warp.Tuple[A, B]] {
+	return func(ctx context.Context, sub chan<- warp.Tuple[A, B]) {
+		defer close(sub)
+
+		ctx, cancel := flow.WithCancel(ctx)
+		defer cancel()
+
+		var (
+			as   = make(chan A)
+			bs   = make(chan B)
+			done = ctx.Done()
+			t    warp.Tuple[A, B]
+			a    A
+			b    B
+			hasA bool
+			hasB bool
+			ok   bool
+		)
+
+		go fa(ctx, as)
+		go fb(ctx, bs)
+
+		for as != nil || bs != nil {
+			select {
+			case <-done:
+				return
+			case a, ok = <-as:
+				if !ok {
+					if !hasA {
+						return
+					}
+					as = nil
+					continue
+				}
+				t.First, hasA = a, true
+			case b, ok = <-bs:
+				if !ok {
+					if !hasB {
+						return
+					}
+					bs = nil
+					continue
+				}
+				t.Second, hasB = b, true
+			}
+			if hasA && hasB && !flow.Emit(done, sub, t) {
+				return
+			}
+		}
+	}
+}
+
+// CombineLatest3 is like CombineLatest but it combines the latest values received
+// from three source events.
+func CombineLatest3[A, B, C any](fa warp.Event[A], fb warp.Event[B], fc warp.Event[C]) warp.Event[warp.Tuple3[A, B, C]] {
+	return func(ctx context.Context, sub chan<- warp.Tuple3[A, B, C]) {
+		defer close(sub)
+
+		ctx, cancel := flow.WithCancel(ctx)
+		defer cancel()
+
+		var (
+			as   = make(chan A)
+			bs   = make(chan B)
+			cs   = make(chan C)
+			done = ctx.Done()
+			t    warp.Tuple3[A, B, C]
+			a    A
+			b    B
+			c    C
+			hasA bool
+			hasB bool
+			hasC bool
+			ok   bool
+		)
+
+		go fa(ctx, as)
+		go fb(ctx, bs)
+		go fc(ctx, cs)
+
+		for as != nil || bs != nil || cs != nil {
+			select {
+			case <-done:
+				return
+			case a, ok = <-as:
+				if !ok {
+					if !hasA {
+						return
+					}
+					as = nil
+					continue
+				}
+				t.First, hasA = a, true
+			case b, ok = <-bs:
+				if !ok {
+					if !hasB {
+						return
+					}
+					bs = nil
+					continue
+				}
+				t.Second, hasB = b, true
+			case c, ok = <-cs:
+				if !ok {
+					if !hasC {
+						return
+					}
+					cs = nil
+					continue
+				}
+				t.Third, hasC = c, true
+			}
+			if hasA && hasB && hasC && !flow.Emit(done, sub, t) {
+				return
+			}
+		}
+	}
+}
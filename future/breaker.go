@@ -0,0 +1,16 @@
+package future
+
+import (
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/breaker"
+	"github.com/onur1/warp/event"
+)
+
+// WithBreaker creates a future which runs each result of a source future through
+// a circuit breaker when it is run, so that it fails with breaker.ErrOpen without
+// running while the breaker is open.
+func WithBreaker[A any](fa warp.Future[A], b *breaker.CircuitBreaker) warp.Future[A] {
+	return warp.Future[A](event.Map(warp.Event[warp.Result[A]](fa), func(ra warp.Result[A]) warp.Result[A] {
+		return breaker.Wrap(b, ra)
+	}))
+}
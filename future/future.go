@@ -148,7 +148,7 @@ func Map[A, B any](fa warp.Future[A], f func(A) B) warp.Future[B] {
 		event.Map(
 			warp.Event[warp.Result[A]](fa),
 			func(ra warp.Result[A]) warp.Result[B] {
-				return result.Map(ra, f)
+				return result.Recover(result.Map(ra, f))
 			},
 		),
 	)
@@ -160,7 +160,7 @@ func Ap[A, B any](fab warp.Future[func(A) B], fa warp.Future[A]) warp.Future[B]
 			warp.Event[warp.Result[func(A) B]](fab),
 			func(gab warp.Result[func(A) B]) func(warp.Result[A]) warp.Result[B] {
 				return func(ga warp.Result[A]) warp.Result[B] {
-					return result.Ap(gab, ga)
+					return result.Recover(result.Ap(gab, ga))
 				}
 			},
 		),
@@ -173,20 +173,59 @@ func Alt[A any](x warp.Future[A], y warp.Future[A]) warp.Future[A] {
 }
 
 func Chain[A, B any](ma warp.Future[A], f func(A) warp.Future[B]) warp.Future[B] {
-	return warp.Future[B](
-		event.Chain(warp.Event[warp.Result[A]](ma), func(ra warp.Result[A]) warp.Event[warp.Result[B]] {
-			return func(ctx context.Context, c chan<- warp.Result[B]) {
-				result.Reduce(ctx, ra, Fail[B], f)(ctx, c)
+	return warp.Future[B](event.Chain(warp.Event[warp.Result[A]](ma), bind(f)))
+}
+
+// SwitchMap creates a future which maps each succeeding value of a source future
+// to an inner future, emitting results only from the most recent inner future and
+// cancelling the context of the previous one.
+func SwitchMap[A, B any](ma warp.Future[A], f func(A) warp.Future[B]) warp.Future[B] {
+	return warp.Future[B](event.SwitchMap(warp.Event[warp.Result[A]](ma), bind(f)))
+}
+
+// MergeMap creates a future which maps each succeeding value of a source future
+// to an inner future, emitting results from at most concurrency inner futures
+// at a time as they occur. A limit which is not positive means no limit.
+func MergeMap[A, B any](ma warp.Future[A], f func(A) warp.Future[B], concurrency int) warp.Future[B] {
+	return warp.Future[B](event.MergeMap(warp.Event[warp.Result[A]](ma), bind(f), concurrency))
+}
+
+// ConcatMap creates a future which maps each succeeding value of a source future
+// to an inner future, emitting the results of the inner futures one inner future
+// after another.
+func ConcatMap[A, B any](ma warp.Future[A], f func(A) warp.Future[B]) warp.Future[B] {
+	return MergeMap(ma, f, 1)
+}
+
+// bind lifts a function returning a future into one which maps a result to an
+// event, failing with the error of a failing result, or with a
+// *result.PanicError if the function panics.
+func bind[A, B any](f func(A) warp.Future[B]) func(warp.Result[A]) warp.Event[warp.Result[B]] {
+	return func(ra warp.Result[A]) warp.Event[warp.Result[B]] {
+		return func(ctx context.Context, c chan<- warp.Result[B]) {
+			result.Reduce(ctx, ra, Fail[B], protect(f))(ctx, c)
+		}
+	}
+}
+
+// protect wraps a function returning a future so that a panic is turned into a
+// future which fails with a *result.PanicError.
+func protect[A, B any](f func(A) warp.Future[B]) func(A) warp.Future[B] {
+	return func(a A) (fb warp.Future[B]) {
+		defer func() {
+			if r := recover(); r != nil {
+				fb = Fail[B](result.NewPanicError(r))
 			}
-		}),
-	)
+		}()
+		return f(a)
+	}
 }
 
 func ChainEvent[A, B any](ma warp.Event[A], f func(A) warp.Future[B]) warp.Future[B] {
 	return warp.Future[B](
 		event.Chain(ma, func(ra A) warp.Event[warp.Result[B]] {
 			return func(ctx context.Context, c chan<- warp.Result[B]) {
-				result.Reduce(ctx, result.Ok(ra), Fail[B], f)(ctx, c)
+				result.Reduce(ctx, result.Ok(ra), Fail[B], protect(f))(ctx, c)
 			}
 		}),
 	)
@@ -211,3 +250,50 @@ func From[A any](as []A) warp.Future[A] {
 	}
 	return warp.Future[A](event.From(ras))
 }
+
+// Timeout creates a future which emits results from a source future, failing with
+// result.ErrTimeout and ending if the source doesn't emit a result within the
+// specified duration, counting from the subscription and from each result. The
+// source is cancelled on timeout.
+func Timeout[A any](fa warp.Future[A], dur time.Duration) warp.Future[A] {
+	return warp.Future[A](event.TimeoutWith(
+		warp.Event[warp.Result[A]](fa),
+		dur,
+		event.Of(result.Error[A](result.ErrTimeout)),
+	))
+}
+
+// Map2 creates a future which pairs the results of two futures in order, like
+// event.Zip, and combines each pair with result.Map2, running both results
+// concurrently.
+func Map2[A, B, C any](fa warp.Future[A], fb warp.Future[B], fn func(A, B) C) warp.Future[C] {
+	return warp.Future[C](event.Map(
+		event.Zip(warp.Event[warp.Result[A]](fa), warp.Event[warp.Result[B]](fb)),
+		func(t warp.Tuple[warp.Result[A], warp.Result[B]]) warp.Result[C] {
+			return result.Map2(t.First, t.Second, fn)
+		},
+	))
+}
+
+// Map3 is like Map2 but it combines the results of three futures.
+func Map3[A, B, C, D any](fa warp.Future[A], fb warp.Future[B], fc warp.Future[C], fn func(A, B, C) D) warp.Future[D] {
+	return warp.Future[D](event.Map(
+		event.Zip3(warp.Event[warp.Result[A]](fa), warp.Event[warp.Result[B]](fb), warp.Event[warp.Result[C]](fc)),
+		func(t warp.Tuple3[warp.Result[A], warp.Result[B], warp.Result[C]]) warp.Result[D] {
+			return result.Map3(t.First, t.Second, t.Third, fn)
+		},
+	))
+}
+
+// Map4 is like Map2 but it combines the results of four futures.
+func Map4[A, B, C, D, E any](fa warp.Future[A], fb warp.Future[B], fc warp.Future[C], fd warp.Future[D], fn func(A, B, C, D) E) warp.Future[E] {
+	return warp.Future[E](event.Map(
+		event.Zip(
+			event.Zip(warp.Event[warp.Result[A]](fa), warp.Event[warp.Result[B]](fb)),
+			event.Zip(warp.Event[warp.Result[C]](fc), warp.Event[warp.Result[D]](fd)),
+		),
+		func(t warp.Tuple[warp.Tuple[warp.Result[A], warp.Result[B]], warp.Tuple[warp.Result[C], warp.Result[D]]]) warp.Result[E] {
+			return result.Map4(t.First.First, t.First.Second, t.Second.First, t.Second.Second, fn)
+		},
+	))
+}
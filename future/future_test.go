@@ -4,13 +4,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/onur1/warp"
+	"github.com/onur1/warp/breaker"
 	"github.com/onur1/warp/event"
 	"github.com/onur1/warp/future"
+	"github.com/onur1/warp/limiter"
 	"github.com/onur1/warp/result"
+	"github.com/onur1/warp/warptest"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -22,10 +29,15 @@ func double(n int) int {
 	return n * 2
 }
 
+func isOdd(n int) bool {
+	return n%2 == 1
+}
+
 var (
 	errFailed = errors.New("failed")
 	errFirst  = errors.New("first")
 	errSecond = errors.New("second")
+	values    = map[rune]int{'a': 1, 'b': 2, 'c': 3, 'd': 4, 'e': 5}
 )
 
 func TestFuture(t *testing.T) {
@@ -135,6 +147,156 @@ func TestFuture(t *testing.T) {
 			),
 			expected: []warp.Result[int]{result.Ok(1), result.Ok(2), result.Ok(3), result.Ok(4), result.Ok(5)},
 		},
+		{
+			desc: "ParallelUnordered",
+			future: future.ParallelUnordered(
+				future.FromResults([]warp.Result[int]{
+					result.After(time.Millisecond*20, 1),
+					result.Ok(2),
+					result.Ok(3),
+				}),
+				future.ParallelOptions[int]{Parallelism: 2},
+			),
+			expected: []warp.Result[int]{result.Ok(2), result.Ok(3), result.Ok(1)},
+		},
+		{
+			desc: "ParallelUnordered (timeout)",
+			future: future.ParallelUnordered(
+				future.FromResults([]warp.Result[int]{
+					result.After(time.Hour, 1),
+					result.Ok(2),
+				}),
+				future.ParallelOptions[int]{Parallelism: 2, Buffer: 1, Timeout: time.Millisecond * 10},
+			),
+			expected: []warp.Result[int]{result.Ok(2), result.Error[int](result.ErrTimeout)},
+		},
+		{
+			desc: "ParallelKeyed",
+			future: future.ParallelKeyed(event.From([]int{1, 2, 3, 4}), isOdd, func(n int) warp.Result[int] {
+				return result.After(map[int]time.Duration{1: 60, 2: 20, 3: 20, 4: 20}[n]*time.Millisecond, n)
+			}, future.ParallelOptions[int]{Parallelism: 2}),
+			expected: []warp.Result[int]{result.Ok(2), result.Ok(4), result.Ok(1), result.Ok(3)},
+		},
+		{
+			desc: "ParallelKeyed (sequential)",
+			future: future.ParallelKeyed(event.From([]int{1, 2, 3, 4}), isOdd, func(n int) warp.Result[int] {
+				return result.After(time.Duration(5-n)*time.Millisecond, n)
+			}, future.ParallelOptions[int]{}),
+			expected: []warp.Result[int]{result.Ok(1), result.Ok(2), result.Ok(3), result.Ok(4)},
+		},
+		{
+			desc: "SwitchMap (fail)",
+			future: future.SwitchMap(future.Fail[int](errFailed), func(n int) warp.Future[int] {
+				return future.Succeed(n)
+			}),
+			expected: []warp.Result[int]{result.Error[int](errFailed)},
+		},
+		{
+			desc:     "Retry",
+			future:   future.Retry(flaky(1), result.MaxAttempts(3)),
+			expected: []warp.Result[int]{result.Ok(1), result.Ok(1), result.Ok(2)},
+		},
+		{
+			desc:   "Retry (exhausted)",
+			future: future.Retry(flaky(3), result.MaxAttempts(2)),
+			expected: []warp.Result[int]{
+				result.Ok(1),
+				result.Ok(1),
+				result.Error[int](&result.RetryError{Errors: []error{errFailed, errFailed}}),
+			},
+		},
+		{
+			desc: "WithBreaker",
+			future: future.WithBreaker(
+				future.FromResults([]warp.Result[int]{
+					result.Ok(1),
+					result.Error[int](errFailed),
+					result.Ok(2),
+				}),
+				breaker.New(breaker.Config{ConsecutiveFailures: 1, Cooldown: time.Hour}),
+			),
+			expected: []warp.Result[int]{
+				result.Ok(1),
+				result.Error[int](errFailed),
+				result.Error[int](breaker.ErrOpen),
+			},
+		},
+		{
+			desc:     "Limit",
+			future:   future.Limit(future.From([]int{1, 2}), limiter.NewSemaphore(1)),
+			expected: []warp.Result[int]{result.Ok(1), result.Ok(2)},
+		},
+		{
+			desc:     "Timeout",
+			future:   future.Timeout(future.From([]int{1, 2}), time.Hour),
+			expected: []warp.Result[int]{result.Ok(1), result.Ok(2)},
+		},
+		{
+			desc:     "Timeout (timeout)",
+			future:   future.Timeout(future.After(time.Hour, 1), time.Millisecond),
+			expected: []warp.Result[int]{result.Error[int](result.ErrTimeout)},
+		},
+		{
+			desc: "Map2",
+			future: future.Map2(future.From([]int{1, 2, 3}), future.From([]int{10, 20}), func(a, b int) int {
+				return a + b
+			}),
+			expected: []warp.Result[int]{result.Ok(11), result.Ok(22)},
+		},
+		{
+			desc: "Map2 (fail)",
+			future: future.Map2(future.From([]int{1}), future.Fail[int](errFailed), func(a, b int) int {
+				return a + b
+			}),
+			expected: []warp.Result[int]{result.Error[int](errFailed)},
+		},
+		{
+			desc: "Map4",
+			future: future.Map4(
+				future.From([]int{1, 2}),
+				future.From([]int{10, 20}),
+				future.From([]int{100, 200}),
+				future.From([]int{1000, 2000}),
+				func(a, b, c, d int) int {
+					return a + b + c + d
+				},
+			),
+			expected: []warp.Result[int]{result.Ok(1111), result.Ok(2222)},
+		},
+		{
+			desc: "Map (panic)",
+			future: future.Map(future.From([]int{1, 0}), func(n int) int {
+				return 2 / n
+			}),
+			expected: []warp.Result[int]{
+				result.Ok(2),
+				result.Error[int](errors.New("panic: runtime error: integer divide by zero")),
+			},
+		},
+		{
+			desc: "Chain (panic)",
+			future: future.Chain(future.From([]int{1, 2}), func(n int) warp.Future[int] {
+				if n == 2 {
+					panic("boom")
+				}
+				return future.Succeed(n)
+			}),
+			expected: []warp.Result[int]{
+				result.Ok(1),
+				result.Error[int](errors.New("panic: boom")),
+			},
+		},
+		{
+			desc: "Parallel (panic)",
+			future: future.Parallel(future.FromResults([]warp.Result[int]{
+				result.Ok(1),
+				func(context.Context) (int, error) { panic("boom") },
+			}), 2),
+			expected: []warp.Result[int]{
+				result.Ok(1),
+				result.Error[int](errors.New("panic: boom")),
+			},
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
@@ -143,6 +305,415 @@ func TestFuture(t *testing.T) {
 	}
 }
 
+func TestParallelConcurrency(t *testing.T) {
+	var (
+		running int32
+		peak    int32
+		opts    = future.ParallelOptions[int]{Parallelism: 2, Buffer: 8}
+		ns      = make([]int, 20)
+	)
+
+	for i := range ns {
+		ns[i] = i
+	}
+
+	track := func(n int) warp.Result[int] {
+		return func(context.Context) (int, error) {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if current <= p || atomic.CompareAndSwapInt32(&peak, p, current) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return n, nil
+		}
+	}
+
+	tracked := make([]warp.Result[int], len(ns))
+	for i, n := range ns {
+		tracked[i] = track(n)
+	}
+
+	testCases := []struct {
+		desc      string
+		future    warp.Future[int]
+		unordered bool
+	}{
+		{
+			desc:   "ParallelWith",
+			future: future.ParallelWith(future.FromResults(tracked), opts),
+		},
+		{
+			desc:      "ParallelUnordered",
+			future:    future.ParallelUnordered(future.FromResults(tracked), opts),
+			unordered: true,
+		},
+		{
+			desc: "ParallelKeyed",
+			future: future.ParallelKeyed(event.From(ns), func(n int) int {
+				return n % 5
+			}, track, opts),
+			unordered: true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			atomic.StoreInt32(&peak, 0)
+
+			ms := event.Reduce(context.TODO(), event.Map(warp.Event[warp.Result[int]](tC.future), func(ra warp.Result[int]) int {
+				n, _ := ra(context.TODO())
+				return n
+			}), nil, func(ms []int, n int) []int {
+				return append(ms, n)
+			})
+
+			if tC.unordered {
+				sort.Ints(ms)
+			}
+
+			assert.Equal(t, ns, ms)
+			assert.Equal(t, int32(2), atomic.LoadInt32(&peak))
+		})
+	}
+}
+
+func TestParallelBuffer(t *testing.T) {
+	var (
+		ran  int32
+		opts = future.ParallelOptions[int]{Parallelism: 2, Buffer: 3}
+		ns   = make([]int, 10)
+	)
+
+	for i := range ns {
+		ns[i] = i
+	}
+
+	count := func(n int) warp.Result[int] {
+		return func(context.Context) (int, error) {
+			atomic.AddInt32(&ran, 1)
+			return n, nil
+		}
+	}
+
+	counted := make([]warp.Result[int], len(ns))
+	for i, n := range ns {
+		counted[i] = count(n)
+	}
+
+	testCases := []struct {
+		desc   string
+		future warp.Future[int]
+	}{
+		{
+			desc:   "ParallelWith",
+			future: future.ParallelWith(future.FromResults(counted), opts),
+		},
+		{
+			desc:   "ParallelUnordered",
+			future: future.ParallelUnordered(future.FromResults(counted), opts),
+		},
+		{
+			desc: "ParallelKeyed",
+			future: future.ParallelKeyed(event.From(ns), func(n int) int {
+				return n % 3
+			}, count, opts),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			atomic.StoreInt32(&ran, 0)
+
+			r := make(chan warp.Result[int])
+
+			go tC.future(context.TODO(), r)
+
+			// The subscriber is busy, so only Parallelism plus Buffer results run.
+			for atomic.LoadInt32(&ran) < 5 {
+				time.Sleep(time.Millisecond)
+			}
+
+			time.Sleep(time.Millisecond * 10)
+
+			assert.Equal(t, int32(5), atomic.LoadInt32(&ran))
+
+			emitted := 0
+			for range r {
+				emitted += 1
+			}
+
+			assert.Equal(t, len(ns), emitted)
+			assert.Equal(t, int32(len(ns)), atomic.LoadInt32(&ran))
+		})
+	}
+}
+
+func TestParallelLeak(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		parallel func(warp.Future[int], future.ParallelOptions[int]) warp.Future[int]
+	}{
+		{
+			desc:     "ParallelWith",
+			parallel: future.ParallelWith[int],
+		},
+		{
+			desc:     "ParallelUnordered",
+			parallel: future.ParallelUnordered[int],
+		},
+		{
+			desc: "ParallelKeyed",
+			parallel: func(fas warp.Future[int], opts future.ParallelOptions[int]) warp.Future[int] {
+				return future.ParallelKeyed(warp.Event[warp.Result[int]](fas), func(warp.Result[int]) bool {
+					return true
+				}, func(ra warp.Result[int]) warp.Result[int] {
+					return ra
+				}, opts)
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			baseline := runtime.NumGoroutine()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			fa := tC.parallel(
+				future.FromResults([]warp.Result[int]{
+					result.Ok(1),
+					result.After(time.Hour, 2),
+					result.After(time.Hour, 3),
+					result.After(time.Hour, 4),
+				}),
+				future.ParallelOptions[int]{Parallelism: 3},
+			)
+
+			r := make(chan warp.Result[int])
+
+			go fa(ctx, r)
+
+			n, err := (<-r)(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, 1, n)
+
+			cancel()
+
+			for range r {
+			}
+
+			assertNoLeak(t, baseline)
+		})
+	}
+}
+
+func TestParallelDiscard(t *testing.T) {
+	var (
+		started  sync.WaitGroup
+		finished = func(n int) warp.Result[int] {
+			return func(context.Context) (int, error) {
+				defer started.Done()
+				return n, nil
+			}
+		}
+		discarded []warp.Result[int]
+	)
+
+	started.Add(2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fa := future.ParallelWith(
+		future.FromResults([]warp.Result[int]{result.After(time.Hour, 1), finished(2), finished(3)}),
+		future.ParallelOptions[int]{
+			Parallelism: 3,
+			OnDiscard: func(ra warp.Result[int]) {
+				discarded = append(discarded, ra)
+			},
+		},
+	)
+
+	r := make(chan warp.Result[int])
+
+	go fa(ctx, r)
+
+	started.Wait()
+
+	cancel()
+
+	for range r {
+		assert.Fail(t, "unexpected result")
+	}
+
+	assert.Len(t, discarded, 3)
+
+	_, err := discarded[0](ctx)
+	assert.Equal(t, context.Canceled, err)
+
+	for i, expected := range []int{2, 3} {
+		n, err := discarded[i+1](ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, n)
+	}
+}
+
+func TestParallelUnorderedDiscard(t *testing.T) {
+	var (
+		started  sync.WaitGroup
+		finished = func(n int) warp.Result[int] {
+			return func(context.Context) (int, error) {
+				defer started.Done()
+				return n, nil
+			}
+		}
+		blocked = func(ctx context.Context) (int, error) {
+			started.Done()
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+		discarded []warp.Result[int]
+	)
+
+	started.Add(3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fa := future.ParallelUnordered(
+		future.FromResults([]warp.Result[int]{blocked, finished(2), finished(3)}),
+		future.ParallelOptions[int]{
+			Parallelism: 3,
+			OnDiscard: func(ra warp.Result[int]) {
+				discarded = append(discarded, ra)
+			},
+		},
+	)
+
+	r := make(chan warp.Result[int])
+
+	go fa(ctx, r)
+
+	started.Wait()
+
+	cancel()
+
+	emitted := 0
+	for range r {
+		emitted += 1
+	}
+
+	assert.Equal(t, 3, emitted+len(discarded))
+}
+
+func TestAll(t *testing.T) {
+	var (
+		ns   []int
+		errs []error
+	)
+
+	for n, err := range future.All(context.TODO(), future.FromResults([]warp.Result[int]{
+		result.Ok(1),
+		result.Error[int](errFailed),
+		result.Ok(2),
+		result.Ok(3),
+	})) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if n == 3 {
+			break
+		}
+		ns = append(ns, n)
+	}
+
+	assert.Equal(t, []int{1, 2}, ns)
+	assert.Equal(t, []error{errFailed}, errs)
+}
+
+// assertNoLeak waits for the number of goroutines to drop back to a baseline,
+// failing if it doesn't within a second.
+func assertNoLeak(t *testing.T, baseline int) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+
+	for runtime.NumGoroutine() > baseline {
+		if time.Now().After(deadline) {
+			t.Errorf("leaked %d goroutines", runtime.NumGoroutine()-baseline)
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		future   func(s *warptest.Scheduler) warp.Future[int]
+		expected string
+	}{
+		{
+			desc: "SwitchMap",
+			future: func(s *warptest.Scheduler) warp.Future[int] {
+				return future.SwitchMap(warptest.ColdFuture(s, "-a--b----|", values, errFailed), inner(s, "-d---d|", "-e-#|"))
+			},
+			expected: "--d--e-#-|",
+		},
+		{
+			desc: "MergeMap",
+			future: func(s *warptest.Scheduler) warp.Future[int] {
+				return future.MergeMap(warptest.ColdFuture(s, "(abc)|", values, errFailed), inner(s, "-----a|", "---#|", "-c|"), 0)
+			},
+			expected: "-c-#-a|",
+		},
+		{
+			desc: "MergeMap (failing source)",
+			future: func(s *warptest.Scheduler) warp.Future[int] {
+				return future.MergeMap(warptest.ColdFuture(s, "(a#)|", values, errFailed), inner(s, "---a|"), 0)
+			},
+			expected: "#--a|",
+		},
+		{
+			desc: "ConcatMap",
+			future: func(s *warptest.Scheduler) warp.Future[int] {
+				return future.ConcatMap(warptest.ColdFuture(s, "(abc)|", values, errFailed), inner(s, "------a|", "---b|", "-c|"))
+			},
+			expected: "------a---b-c|",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := warptest.NewScheduler()
+			warptest.ExpectFuture(t, s, tC.future(s), tC.expected, values, errFailed)
+		})
+	}
+}
+
+// inner creates a function which maps n to a cold future described by the nth
+// marble, counting from 1.
+func inner(s *warptest.Scheduler, marbles ...string) func(int) warp.Future[int] {
+	return func(n int) warp.Future[int] {
+		return warptest.ColdFuture(s, marbles[n-1], values, errFailed)
+	}
+}
+
+// flaky creates a future which emits 1 followed by an error for the first n times
+// it is subscribed to, and 1 followed by 2 afterwards.
+func flaky(n int) warp.Future[int] {
+	var subscriptions int32
+	return func(ctx context.Context, sub chan<- warp.Result[int]) {
+		if int(atomic.AddInt32(&subscriptions, 1)) <= n {
+			future.FromResults([]warp.Result[int]{result.Ok(1), result.Error[int](errFailed)})(ctx, sub)
+		} else {
+			future.From([]int{1, 2})(ctx, sub)
+		}
+	}
+}
+
 func assertEq(t *testing.T, dequeue warp.Future[int], expected []warp.Result[int], unordered bool) {
 	r := make(chan warp.Result[int])
 
@@ -0,0 +1,22 @@
+package future
+
+import (
+	"context"
+	"iter"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/event"
+)
+
+// All returns an iterator over the outcomes of the results of a future, running
+// each result with ctx as it is received. Breaking out of the loop cancels the
+// future, and the iterator waits for it to end before returning.
+func All[A any](ctx context.Context, fa warp.Future[A]) iter.Seq2[A, error] {
+	return func(yield func(A, error) bool) {
+		for ra := range event.All(ctx, warp.Event[warp.Result[A]](fa)) {
+			if !yield(ra(ctx)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package future
+
+import (
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/event"
+	"github.com/onur1/warp/limiter"
+	"github.com/onur1/warp/result"
+)
+
+// Limit creates a future which runs each result of a source future through a
+// limiter when it is run, like result.Limit.
+func Limit[A any](fa warp.Future[A], l limiter.Limiter) warp.Future[A] {
+	return warp.Future[A](event.Map(warp.Event[warp.Result[A]](fa), func(ra warp.Result[A]) warp.Result[A] {
+		return result.Limit(ra, l)
+	}))
+}
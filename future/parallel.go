@@ -2,190 +2,401 @@ package future
 
 import (
 	"context"
-	"fmt"
+	"sort"
+	"sync"
+	"time"
 
-	"github.com/onur1/ring"
 	"github.com/onur1/warp"
+	"github.com/onur1/warp/internal/flow"
 	"github.com/onur1/warp/result"
 )
 
-type par[A any] struct {
-	head int
-	tail int
-	b    *ring.Ring[A]
+// ParallelOptions configures how futures run results in parallel.
+type ParallelOptions[A any] struct {
+	// Parallelism is the number of results which may run at the same time. It
+	// defaults to 1.
+	Parallelism int
+	// Buffer is the number of finished results which may be held on top of
+	// Parallelism while they wait to be emitted, because the subscriber is busy
+	// or, if the order of the results is kept, because an earlier result is
+	// still running. No new result starts while Parallelism plus Buffer results
+	// have started but have not been emitted yet.
+	Buffer int
+	// Timeout is how long each result may run before failing with
+	// result.ErrTimeout. Results may run for any amount of time if it is zero.
+	Timeout time.Duration
+	// OnDiscard is called with each result which has finished but is not emitted
+	// because the context has been cancelled or the subscriber has stopped,
+	// including the results which have been cancelled themselves. Results which
+	// have not started yet are neither run nor reported. It is called from the
+	// goroutine of the future, one result at a time, before the future ends.
+	OnDiscard func(warp.Result[A])
 }
 
-func newPar[A any](limit int) *par[A] {
-	s := new(par[A])
-	s.b = ring.NewRing[A](limit)
-	s.head, s.tail = 0, 0
-	return s
-}
-
-func (s *par[A]) Flush() []A {
-	diff := s.head - s.tail
-	v := make([]A, diff)
-	for i := s.tail; i != s.head; i++ {
-		v[diff-s.head+i] = s.b.Del(i)
+func (opts ParallelOptions[A]) parallelism() int {
+	if opts.Parallelism < 1 {
+		return 1
 	}
-	return v
-}
-
-func (s *par[A]) Limit() int {
-	return s.b.Size()
-}
-
-type indexed[A any] interface {
-	Result() warp.Result[A]
-	Index() int
-}
-
-type indexedError[A any] struct {
-	error
-	index int
+	return opts.Parallelism
 }
 
-func (err indexedError[A]) Index() int {
-	return err.index
-}
-
-func (err indexedError[A]) Error() string {
-	return err.error.Error()
-}
-
-func newIndexedError[A any](index int, err error) indexed[A] {
-	return indexedError[A]{error: err, index: index}
+func (opts ParallelOptions[A]) buffer() int {
+	if opts.Buffer < 0 {
+		return 0
+	}
+	return opts.Buffer
 }
 
-func (va indexedError[A]) Result() warp.Result[A] {
-	return result.Error[A](va.error)
+func (opts ParallelOptions[A]) discard(ra warp.Result[A]) {
+	if opts.OnDiscard != nil {
+		opts.OnDiscard(ra)
+	}
 }
 
-type indexedValue[A any] struct {
-	a     A
-	index int
-}
+// settle runs a result with a context of its own and with the per-item timeout of
+// the options, returning a result which holds its outcome. A panic is turned into
+// a *result.PanicError.
+func settle[A, B any](ctx context.Context, ra warp.Result[A], opts ParallelOptions[B]) warp.Result[A] {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-func (va indexedValue[A]) Index() int {
-	return va.index
-}
+	if opts.Timeout > 0 {
+		ra = result.Timeout(ra, opts.Timeout)
+	}
 
-func (va indexedValue[A]) Result() warp.Result[A] {
-	return result.Ok(va.a)
-}
+	a, err := result.Recover(ra)(ctx)
+	if err != nil {
+		return result.Error[A](err)
+	}
 
-func newIndexedValue[A any](index int, a A) indexed[A] {
-	return indexedValue[A]{a: a, index: index}
+	return result.Ok(a)
 }
 
 // Parallel creates a Future which emits a stream of Results in parallel while keeping
 // the sequence of output same as the order of input.
 func Parallel[A any](fas warp.Future[A], parallelism int) warp.Future[A] {
+	return ParallelWith(fas, ParallelOptions[A]{Parallelism: parallelism})
+}
+
+// ParallelWith is like Parallel but it is configured with options. At most
+// Parallelism results run at a time. Once the context is cancelled or the
+// subscriber stops, the results which are still running are cancelled, and the
+// future waits for them and for the source future to end before ending itself.
+func ParallelWith[A any](fas warp.Future[A], opts ParallelOptions[A]) warp.Future[A] {
+	type indexed struct {
+		index int
+		ra    warp.Result[A]
+	}
+
 	return func(ctx context.Context, sub chan<- warp.Result[A]) {
 		defer close(sub)
 
+		ctx, cancel := flow.WithCancel(ctx)
+
 		var (
-			cra = make(chan warp.Result[A])
-			fa  warp.Result[A]
-			ok  bool
+			ras      = make(chan warp.Result[A])
+			reads    = ras
+			n        = opts.parallelism()
+			limit    = n + opts.buffer()
+			finished = make(chan indexed, n)
+			pending  = make(map[int]warp.Result[A])
+			done     = ctx.Done()
+			head     = 0
+			next     = 0
+			running  = 0
+			ended    = false
+			wg       sync.WaitGroup
 		)
 
-		var done <-chan struct{}
+		go fas(ctx, ras)
 
-		if ctx != nil {
-			done = ctx.Done()
-		}
+		defer func() {
+			cancel()
 
-		go fas(ctx, cra)
+			for range ras {
+			}
 
-		var (
-			s       = newPar[warp.Result[A]](parallelism)
-			writes  = make(chan indexed[A])
-			reads   = cra
-			limit   = s.Limit()
-			pending = 0
-		)
+			wg.Wait()
+			close(finished)
+
+			for r := range finished {
+				pending[r.index] = r.ra
+			}
+
+			indices := make([]int, 0, len(pending))
+			for i := range pending {
+				indices = append(indices, i)
+			}
+			sort.Ints(indices)
 
-		var res indexed[A]
+			for _, i := range indices {
+				opts.discard(pending[i])
+			}
+		}()
 
-	LOOP:
-		for {
+		for reads != nil || next < head {
 			select {
 			case <-done:
-				reads, done = nil, nil
-				if s.head == s.tail {
-					break LOOP
-				}
+				return
 			default:
-				select {
-				case <-done:
-					reads, done = nil, nil
-					if s.head == s.tail {
-						break LOOP
-					}
-					break
-				case fa, ok = <-reads:
-					if !ok {
-						if s.head == s.tail {
-							break LOOP
-						}
-						reads = nil
-						break
-					}
+			}
 
-					go func(fa warp.Result[A], pos int) {
-						a, err := fa(ctx)
-						if err != nil {
-							writes <- newIndexedError[A](pos, err)
-						} else {
-							writes <- newIndexedValue(pos, a)
-						}
-					}(fa, s.head)
+			var sends chan<- warp.Result[A]
 
-					s.head += 1
+			ra, ready := pending[next]
+			if ready {
+				sends = sub
+			}
 
-					if (s.head - s.tail) < limit {
-						break
-					}
+			select {
+			case <-done:
+				return
+			case sends <- ra:
+				delete(pending, next)
+				next += 1
+			case ra, ok := <-reads:
+				if !ok {
+					reads, ended = nil, true
+					continue
+				}
 
-					reads = nil
-				case res = <-writes:
-					ra := res.Result()
+				wg.Add(1)
+
+				go func(index int, ra warp.Result[A]) {
+					defer wg.Done()
+					finished <- indexed{index, settle(ctx, ra, opts)}
+				}(head, ra)
+
+				head += 1
+				running += 1
+			case r := <-finished:
+				pending[r.index] = r.ra
+				running -= 1
+			}
+
+			if !ended && running < n && head-next < limit {
+				reads = ras
+			} else {
+				reads = nil
+			}
+		}
+	}
+}
+
+// ParallelUnordered is like ParallelWith but it emits each result as soon as it has
+// finished, so that a slow result doesn't hold up the ones after it.
+func ParallelUnordered[A any](fas warp.Future[A], opts ParallelOptions[A]) warp.Future[A] {
+	return func(ctx context.Context, sub chan<- warp.Result[A]) {
+		defer close(sub)
+
+		ctx, cancel := flow.WithCancel(ctx)
+
+		var (
+			ras   = make(chan warp.Result[A])
+			limit = opts.parallelism() + opts.buffer()
+			slots = make(chan struct{}, limit)
+			out   = make(chan warp.Result[A], limit)
+			done  = ctx.Done()
+			wg    sync.WaitGroup
+		)
 
-					s.b.Put(res.Index(), ra)
+		go fas(ctx, ras)
 
-					pending += 1
+		for i := 0; i < opts.parallelism(); i++ {
+			wg.Add(1)
 
-					for s.b.Get(s.tail) != nil {
-						ra = s.b.Del(s.tail)
+			go func() {
+				defer wg.Done()
 
-						s.tail += 1
-						pending -= 1
+				for {
+					select {
+					case <-done:
+						return
+					case slots <- struct{}{}:
+					}
 
+					select {
+					case <-done:
+						return
+					case ra, ok := <-ras:
+						if !ok {
+							return
+						}
 						select {
 						case <-done:
-							break LOOP
+							return
 						default:
-							select {
-							case <-done:
-								break LOOP
-							case sub <- ra:
-							}
 						}
-					}
-
-					if (s.head - s.tail) < limit {
-						reads = cra
+						out <- settle(ctx, ra, opts)
 					}
 				}
+			}()
+		}
+
+		go func() {
+			wg.Wait()
+			close(out)
+		}()
+
+		defer func() {
+			cancel()
+
+			for ra := range out {
+				opts.discard(ra)
+			}
+
+			for range ras {
 			}
+		}()
+
+		for ra := range out {
+			if !flow.Emit(done, sub, ra) {
+				opts.discard(ra)
+				return
+			}
+			<-slots
 		}
+	}
+}
 
-		parBuffer := s.Flush()
-		if len(parBuffer) > 0 {
-			fmt.Printf("parallel buffer: %+v", parBuffer)
+// ParallelKeyed creates a future by mapping each value received from a source
+// event to a result, running the results of different keys in parallel while
+// running the results of the same key one after another, like a partitioned
+// worker pool. The results of each key are emitted in the order of their values,
+// as soon as they have finished.
+func ParallelKeyed[A, B any, K comparable](fa warp.Event[A], key func(A) K, f func(A) warp.Result[B], opts ParallelOptions[B]) warp.Future[B] {
+	type keyed struct {
+		k  K
+		rb warp.Result[B]
+	}
+
+	return func(ctx context.Context, sub chan<- warp.Result[B]) {
+		defer close(sub)
+
+		ctx, cancel := flow.WithCancel(ctx)
+
+		var (
+			as       = make(chan A)
+			reads    = as
+			n        = opts.parallelism()
+			limit    = n + opts.buffer()
+			finished = make(chan keyed, n)
+			done     = ctx.Done()
+			queues   = make(map[K][]A)
+			waiting  []K
+			out      []warp.Result[B]
+			queued   = 0
+			active   = 0
+			inflight = 0
+			wg       sync.WaitGroup
+		)
+
+		start := func(k K, a A) {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+				finished <- keyed{k, settle(ctx, func(ctx context.Context) (B, error) {
+					return f(a)(ctx)
+				}, opts)}
+			}()
 		}
 
-		close(writes)
+		// schedule starts the next values of the waiting keys, as long as there are
+		// free slots.
+		schedule := func() {
+			for active < n && inflight < limit && len(waiting) > 0 {
+				k := waiting[0]
+				waiting = waiting[1:]
+				q := queues[k]
+				queues[k] = q[1:]
+				queued -= 1
+				active += 1
+				inflight += 1
+				start(k, q[0])
+			}
+		}
+
+		go fa(ctx, as)
+
+		defer func() {
+			cancel()
+
+			if as != nil {
+				for range as {
+				}
+			}
+
+			wg.Wait()
+			close(finished)
+
+			for _, rb := range out {
+				opts.discard(rb)
+			}
+
+			for r := range finished {
+				opts.discard(r.rb)
+			}
+		}()
+
+		for reads != nil || inflight > 0 || len(waiting) > 0 {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			var (
+				sends chan<- warp.Result[B]
+				next  warp.Result[B]
+			)
+
+			if len(out) > 0 {
+				sends, next = sub, out[0]
+			}
+
+			select {
+			case <-done:
+				return
+			case sends <- next:
+				out = out[1:]
+				inflight -= 1
+			case a, ok := <-reads:
+				if !ok {
+					as, reads = nil, nil
+					continue
+				}
+
+				k := key(a)
+
+				if q, ok := queues[k]; ok {
+					queues[k] = append(q, a)
+				} else {
+					queues[k] = []A{a}
+					waiting = append(waiting, k)
+				}
+
+				queued += 1
+			case r := <-finished:
+				out = append(out, r.rb)
+				active -= 1
+
+				// The next value of the key goes first, so that a busy key keeps
+				// its turn.
+				if len(queues[r.k]) > 0 {
+					waiting = append([]K{r.k}, waiting...)
+				} else {
+					delete(queues, r.k)
+				}
+			}
+
+			schedule()
+
+			if queued < n && as != nil {
+				reads = as
+			} else {
+				reads = nil
+			}
+		}
 	}
 }
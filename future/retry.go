@@ -0,0 +1,53 @@
+package future
+
+import (
+	"context"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/internal/flow"
+	"github.com/onur1/warp/result"
+)
+
+// Retry creates a future which emits the succeeding results of a source future,
+// re-subscribing to the source when it emits a failing result for as long as the
+// policy allows. Since the source is subscribed to from scratch, its values may
+// be emitted more than once. If the source keeps failing, a *result.RetryError is
+// emitted as the last result.
+func Retry[A any](fa warp.Future[A], policy result.RetryPolicy) warp.Future[A] {
+	return func(ctx context.Context, sub chan<- warp.Result[A]) {
+		defer close(sub)
+
+		ctx, cancel := flow.WithCancel(ctx)
+		defer cancel()
+
+		done := ctx.Done()
+
+		attempt := func(ctx context.Context) (_ struct{}, err error) {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			var (
+				ras = make(chan warp.Result[A])
+				a   A
+			)
+
+			go fa(ctx, ras)
+
+			for ra := range ras {
+				if a, err = ra(ctx); err != nil {
+					return
+				}
+				if !flow.Emit(done, sub, result.Ok(a)) {
+					err = ctx.Err()
+					return
+				}
+			}
+
+			return
+		}
+
+		if _, err := result.Retry(attempt, policy)(ctx); err != nil {
+			flow.Emit(done, sub, result.Error[A](err))
+		}
+	}
+}
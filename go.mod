@@ -1,11 +1,8 @@
 module github.com/onur1/warp
 
-go 1.19
+go 1.23
 
-require (
-	github.com/onur1/ring v0.0.3
-	github.com/stretchr/testify v1.8.1
-)
+require github.com/stretchr/testify v1.8.1
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
@@ -0,0 +1,74 @@
+// Package flow implements the helpers which the packages of warp share to run
+// events and results with a context that may be nil.
+package flow
+
+import (
+	"context"
+	"time"
+
+	"github.com/onur1/warp/clock"
+)
+
+// WithCancel is like context.WithCancel but it also accepts a nil context.
+func WithCancel(ctx context.Context) (context.Context, context.CancelFunc) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithCancel(ctx)
+}
+
+// Detach returns a context which carries the values of a context but is never
+// cancelled. It also accepts a nil context.
+func Detach(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+	return context.WithoutCancel(ctx)
+}
+
+// Emit sends a value to a subscriber unless done is closed first, and reports
+// whether the value was delivered.
+func Emit[A any](done <-chan struct{}, sub chan<- A, a A) bool {
+	select {
+	case <-done:
+		return false
+	default:
+	}
+
+	select {
+	case <-done:
+		return false
+	case sub <- a:
+		return true
+	}
+}
+
+// Sleep waits for the specified duration on a clock, returning the error of the
+// context if it is cancelled first.
+func Sleep(ctx context.Context, c clock.Clock, dur time.Duration) error {
+	var done <-chan struct{}
+
+	if ctx != nil {
+		done = ctx.Done()
+	}
+
+	select {
+	case <-done:
+		return ctx.Err()
+	default:
+	}
+
+	if dur <= 0 {
+		return nil
+	}
+
+	timer := c.NewTimer(dur)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return ctx.Err()
+	case <-timer.C():
+		return nil
+	}
+}
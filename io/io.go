@@ -66,6 +66,36 @@ func ChainRec[A, B any](init A, f func(A) warp.IO[func() (A, B, bool)]) warp.IO[
 	}
 }
 
+func Sequence[A any](mas []warp.IO[A]) warp.IO[[]A] {
+	return func() []A {
+		as := make([]A, len(mas))
+		for i, ma := range mas {
+			as[i] = ma()
+		}
+		return as
+	}
+}
+
+func Traverse[A, B any](as []A, f func(A) warp.IO[B]) warp.IO[[]B] {
+	return func() []B {
+		bs := make([]B, len(as))
+		for i, a := range as {
+			bs[i] = f(a)()
+		}
+		return bs
+	}
+}
+
+func TraverseMap[K comparable, A, B any](m map[K]A, f func(K, A) warp.IO[B]) warp.IO[map[K]B] {
+	return func() map[K]B {
+		bs := make(map[K]B, len(m))
+		for k, a := range m {
+			bs[k] = f(k, a)()
+		}
+		return bs
+	}
+}
+
 func Of[A any](a A) warp.IO[A] {
 	return func() A {
 		return a
@@ -79,3 +79,13 @@ func TestIO(t *testing.T) {
 func double(n int) int {
 	return n * 2
 }
+
+func TestTraverse(t *testing.T) {
+	assert.Equal(t, []int{1, 2}, io.Sequence([]warp.IO[int]{io.Of(1), io.Of(2)})())
+	assert.Equal(t, []int{2, 4}, io.Traverse([]int{1, 2}, func(n int) warp.IO[int] {
+		return io.Of(double(n))
+	})())
+	assert.Equal(t, map[string]int{"a": 2}, io.TraverseMap(map[string]int{"a": 1}, func(_ string, n int) warp.IO[int] {
+		return io.Of(double(n))
+	})())
+}
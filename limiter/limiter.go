@@ -0,0 +1,237 @@
+// Package limiter implements rate limiters and concurrency limiters which can be
+// shared across results, events and futures.
+package limiter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/onur1/warp/clock"
+	"github.com/onur1/warp/internal/flow"
+)
+
+// ErrLimited is the error of an operation which is rejected by a limiter instead
+// of waiting.
+var ErrLimited = errors.New("limiter: limit exceeded")
+
+// A Limiter decides when an operation may proceed. Time is told by the clock
+// carried by the context. Implementations are safe for concurrent use.
+type Limiter interface {
+	// Acquire blocks until an operation may proceed, returning a function which
+	// must be called once the operation has finished. It fails with the error of
+	// the context if it is cancelled first, or with ErrLimited if the limiter
+	// rejects the operation.
+	Acquire(ctx context.Context) (release func(), err error)
+	// TryAcquire is like Acquire but it doesn't block, reporting whether the
+	// operation may proceed right away.
+	TryAcquire(ctx context.Context) (release func(), ok bool)
+}
+
+func noop() {}
+
+// A TokenBucket is a limiter which holds up to a number of tokens, each of which
+// lets one operation through, and which gains a new token every interval.
+type TokenBucket struct {
+	interval time.Duration
+	burst    int
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket creates a token bucket which starts full, letting burst
+// operations through at once and one operation every interval afterwards.
+func NewTokenBucket(interval time.Duration, burst int) *TokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucket{
+		interval: interval,
+		burst:    burst,
+		tokens:   float64(burst),
+	}
+}
+
+// Acquire takes a token, waiting for one to become available if the bucket is
+// empty. Waiting operations are served in order.
+func (b *TokenBucket) Acquire(ctx context.Context) (func(), error) {
+	c := clock.FromContext(ctx)
+
+	b.mu.Lock()
+	b.fill(c.Now())
+	b.tokens--
+	wait := time.Duration(-b.tokens * float64(b.interval))
+	b.mu.Unlock()
+
+	if err := flow.Sleep(ctx, c, wait); err != nil {
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return nil, err
+	}
+
+	return noop, nil
+}
+
+// TryAcquire takes a token if there is one.
+func (b *TokenBucket) TryAcquire(ctx context.Context) (func(), bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.fill(clock.FromContext(ctx).Now())
+
+	if b.tokens < 1 {
+		return nil, false
+	}
+
+	b.tokens--
+
+	return noop, true
+}
+
+// fill adds the tokens gained since the last time the bucket was used.
+func (b *TokenBucket) fill(now time.Time) {
+	if !b.last.IsZero() && b.interval > 0 {
+		b.tokens += float64(now.Sub(b.last)) / float64(b.interval)
+	} else if b.interval <= 0 {
+		b.tokens = float64(b.burst)
+	}
+	if b.tokens > float64(b.burst) {
+		b.tokens = float64(b.burst)
+	}
+	b.last = now
+}
+
+// A LeakyBucket is a limiter which lets operations through evenly spaced by an
+// interval, queueing up to a number of operations and rejecting the rest with
+// ErrLimited.
+type LeakyBucket struct {
+	interval time.Duration
+	capacity int
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// NewLeakyBucket creates a leaky bucket which lets one operation through every
+// interval, with at most capacity operations waiting for their turn.
+func NewLeakyBucket(interval time.Duration, capacity int) *LeakyBucket {
+	return &LeakyBucket{
+		interval: interval,
+		capacity: capacity,
+	}
+}
+
+// Acquire waits for the turn of an operation, failing with ErrLimited if the
+// bucket is already full.
+func (b *LeakyBucket) Acquire(ctx context.Context) (func(), error) {
+	c := clock.FromContext(ctx)
+
+	b.mu.Lock()
+
+	now := c.Now()
+
+	slot := b.next
+	if slot.Before(now) {
+		slot = now
+	}
+
+	wait := slot.Sub(now)
+
+	if b.interval > 0 && int(wait/b.interval) > b.capacity {
+		b.mu.Unlock()
+		return nil, ErrLimited
+	}
+
+	b.next = slot.Add(b.interval)
+
+	b.mu.Unlock()
+
+	if err := flow.Sleep(ctx, c, wait); err != nil {
+		b.mu.Lock()
+		if b.next.Equal(slot.Add(b.interval)) {
+			b.next = slot
+		}
+		b.mu.Unlock()
+		return nil, err
+	}
+
+	return noop, nil
+}
+
+// TryAcquire lets an operation through if it doesn't have to wait for its turn.
+func (b *LeakyBucket) TryAcquire(ctx context.Context) (func(), bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := clock.FromContext(ctx).Now()
+
+	if now.Before(b.next) {
+		return nil, false
+	}
+
+	b.next = now.Add(b.interval)
+
+	return noop, true
+}
+
+// A Semaphore is a limiter which lets a number of operations run at the same time,
+// isolating the callers which share it from each other like a bulkhead.
+type Semaphore struct {
+	slots chan struct{}
+}
+
+// NewSemaphore creates a semaphore which lets at most n operations run at the
+// same time.
+func NewSemaphore(n int) *Semaphore {
+	if n < 1 {
+		n = 1
+	}
+	return &Semaphore{slots: make(chan struct{}, n)}
+}
+
+// Acquire waits for a free slot.
+func (s *Semaphore) Acquire(ctx context.Context) (func(), error) {
+	var done <-chan struct{}
+
+	if ctx != nil {
+		done = ctx.Done()
+	}
+
+	select {
+	case <-done:
+		return nil, ctx.Err()
+	default:
+	}
+
+	select {
+	case <-done:
+		return nil, ctx.Err()
+	case s.slots <- struct{}{}:
+		return s.release(), nil
+	}
+}
+
+// TryAcquire takes a free slot if there is one.
+func (s *Semaphore) TryAcquire(_ context.Context) (func(), bool) {
+	select {
+	case s.slots <- struct{}{}:
+		return s.release(), true
+	default:
+		return nil, false
+	}
+}
+
+// release returns a function which frees a slot once, no matter how many times
+// it is called.
+func (s *Semaphore) release() func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			<-s.slots
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package limiter_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onur1/warp/clock"
+	"github.com/onur1/warp/clock/clocktest"
+	"github.com/onur1/warp/limiter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucket(t *testing.T) {
+	var (
+		c   = clocktest.NewClock(time.Unix(0, 0))
+		ctx = clock.WithClock(context.Background(), c)
+		l   = limiter.NewTokenBucket(time.Second, 2)
+	)
+
+	assert.True(t, tryAcquire(ctx, l))
+	assert.True(t, tryAcquire(ctx, l))
+	assert.False(t, tryAcquire(ctx, l))
+
+	c.Advance(time.Second)
+
+	assert.True(t, tryAcquire(ctx, l))
+	assert.False(t, tryAcquire(ctx, l))
+
+	errc := acquire(ctx, l)
+
+	c.BlockUntil(1)
+	c.Advance(time.Second)
+
+	assert.NoError(t, <-errc)
+	assert.False(t, tryAcquire(ctx, l))
+
+	cctx, cancel := context.WithCancel(ctx)
+
+	errc = acquire(cctx, l)
+
+	c.BlockUntil(1)
+	cancel()
+
+	assert.Equal(t, context.Canceled, <-errc)
+
+	c.Advance(time.Second)
+
+	assert.True(t, tryAcquire(ctx, l))
+}
+
+func TestLeakyBucket(t *testing.T) {
+	var (
+		c   = clocktest.NewClock(time.Unix(0, 0))
+		ctx = clock.WithClock(context.Background(), c)
+		l   = limiter.NewLeakyBucket(time.Second, 1)
+	)
+
+	assert.True(t, tryAcquire(ctx, l))
+	assert.False(t, tryAcquire(ctx, l))
+
+	errc := acquire(ctx, l)
+
+	c.BlockUntil(1)
+
+	_, err := l.Acquire(ctx)
+	assert.Equal(t, limiter.ErrLimited, err)
+
+	c.Advance(time.Second)
+
+	assert.NoError(t, <-errc)
+	assert.False(t, tryAcquire(ctx, l))
+
+	c.Advance(time.Second)
+
+	assert.True(t, tryAcquire(ctx, l))
+}
+
+func TestSemaphore(t *testing.T) {
+	l := limiter.NewSemaphore(1)
+
+	release, err := l.Acquire(context.TODO())
+	assert.NoError(t, err)
+
+	_, ok := l.TryAcquire(context.TODO())
+	assert.False(t, ok)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = l.Acquire(ctx)
+	assert.Equal(t, context.Canceled, err)
+
+	release()
+	release()
+
+	release, ok = l.TryAcquire(context.TODO())
+	assert.True(t, ok)
+
+	_, ok = l.TryAcquire(context.TODO())
+	assert.False(t, ok)
+
+	release()
+}
+
+func tryAcquire(ctx context.Context, l limiter.Limiter) bool {
+	_, ok := l.TryAcquire(ctx)
+	return ok
+}
+
+func acquire(ctx context.Context, l limiter.Limiter) <-chan error {
+	errc := make(chan error, 1)
+	go func() {
+		_, err := l.Acquire(ctx)
+		errc <- err
+	}()
+	return errc
+}
@@ -102,3 +102,44 @@ func Attempt[A any](f warp.IO[A]) warp.Nilable[A] {
 	}()
 	return Some(f())
 }
+
+// Sequence creates a nilable from a slice of nilables which holds all of their
+// values, or nil if any of them is nil.
+func Sequence[A any](mas []warp.Nilable[A]) warp.Nilable[[]A] {
+	as := make([]A, len(mas))
+	for i, ma := range mas {
+		if ma == nil {
+			return nil
+		}
+		as[i] = *ma
+	}
+	return Some(as)
+}
+
+// Traverse creates a nilable by applying a function which returns a nilable on
+// each value of a slice, returning nil if any of the results is nil.
+func Traverse[A, B any](as []A, f func(A) warp.Nilable[B]) warp.Nilable[[]B] {
+	bs := make([]B, len(as))
+	for i, a := range as {
+		mb := f(a)
+		if mb == nil {
+			return nil
+		}
+		bs[i] = *mb
+	}
+	return Some(bs)
+}
+
+// TraverseMap creates a nilable by applying a function which returns a nilable on
+// each entry of a map, returning nil if any of the results is nil.
+func TraverseMap[K comparable, A, B any](m map[K]A, f func(K, A) warp.Nilable[B]) warp.Nilable[map[K]B] {
+	bs := make(map[K]B, len(m))
+	for k, a := range m {
+		mb := f(k, a)
+		if mb == nil {
+			return nil
+		}
+		bs[k] = *mb
+	}
+	return Some(bs)
+}
@@ -74,6 +74,23 @@ func TestNilable(t *testing.T) {
 	}
 }
 
+func TestTraverse(t *testing.T) {
+	positive := func(n int) warp.Nilable[int] {
+		return nilable.FromPredicate(n, func(n int) bool { return n > 0 })
+	}
+
+	positiveAt := func(_ string, n int) warp.Nilable[int] {
+		return positive(n)
+	}
+
+	assert.Equal(t, []int{1, 2}, *nilable.Sequence([]warp.Nilable[int]{nilable.Some(1), nilable.Some(2)}))
+	assert.Nil(t, nilable.Sequence([]warp.Nilable[int]{nilable.Some(1), nilable.Nil[int]()}))
+	assert.Equal(t, []int{1, 2}, *nilable.Traverse([]int{1, 2}, positive))
+	assert.Nil(t, nilable.Traverse([]int{1, -2}, positive))
+	assert.Equal(t, map[string]int{"a": 1}, *nilable.TraverseMap(map[string]int{"a": 1}, positiveAt))
+	assert.Nil(t, nilable.TraverseMap(map[string]int{"a": 1, "b": -1}, positiveAt))
+}
+
 func assertEq(t *testing.T, v warp.Nilable[int], expected int) {
 	if v == nil {
 		assert.Equal(t, expected, 0)
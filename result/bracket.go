@@ -0,0 +1,42 @@
+package result
+
+import (
+	"context"
+	"errors"
+
+	"github.com/onur1/warp"
+)
+
+// Bracket creates a result which acquires a resource, uses it to run another
+// result, and then releases it. The resource is released exactly once whether
+// use succeeds, fails, panics or is cancelled through the context, and an error
+// returned by release is joined into the error of the result. Nothing is released
+// if acquire fails.
+func Bracket[R, A any](acquire warp.Result[R], use func(R) warp.Result[A], release func(R) error) warp.Result[A] {
+	return func(ctx context.Context) (a A, err error) {
+		var r R
+
+		if r, err = acquire(ctx); err != nil {
+			return
+		}
+
+		defer func() {
+			err = join(err, release(r))
+		}()
+
+		return use(r)(ctx)
+	}
+}
+
+// join is like errors.Join but it returns the other error as it is if one of
+// them is nil.
+func join(err, other error) error {
+	switch {
+	case other == nil:
+		return err
+	case err == nil:
+		return other
+	default:
+		return errors.Join(err, other)
+	}
+}
@@ -0,0 +1,209 @@
+package result
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/clock"
+	"github.com/onur1/warp/internal/flow"
+)
+
+// A CacheOption configures the caching behaviour of Memoize and Cached.
+type CacheOption func(*cacheConfig)
+
+type cacheConfig struct {
+	refreshAhead time.Duration
+	cacheErrors  bool
+}
+
+// RefreshAhead makes a cached value be refreshed in the background when it is
+// requested within the specified duration before it expires, so that callers
+// keep being served the cached value while it is being refreshed.
+func RefreshAhead(dur time.Duration) CacheOption {
+	return func(c *cacheConfig) {
+		c.refreshAhead = dur
+	}
+}
+
+// CacheErrors makes failing results be cached like succeeding ones. Errors of
+// cancelled or expired contexts are never cached.
+func CacheErrors() CacheOption {
+	return func(c *cacheConfig) {
+		c.cacheErrors = true
+	}
+}
+
+// Memoize creates a result which runs a result only once, returning the same value
+// whenever it is run afterwards. Concurrent callers share a single execution, and
+// a failing result is run again by the next caller unless CacheErrors is given.
+func Memoize[A any](ma warp.Result[A], opts ...CacheOption) warp.Result[A] {
+	return Cached(ma, 0, opts...)
+}
+
+// Cached is like Memoize but a cached value expires once the specified duration
+// has passed on the clock carried by the context, after which the result is run
+// again by the next caller. A duration which is not positive means that values
+// never expire.
+func Cached[A any](ma warp.Result[A], ttl time.Duration, opts ...CacheOption) warp.Result[A] {
+	var config cacheConfig
+
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	c := &cache[A]{ma: Recover(ma), ttl: ttl, config: config}
+
+	return func(ctx context.Context) (a A, err error) {
+		for {
+			f := c.get(ctx)
+
+			if a, err = f.wait(ctx); !f.abandoned(ctx) {
+				return
+			}
+		}
+	}
+}
+
+// SingleFlight creates a function which returns a result for each key, such that
+// concurrent callers of the results of the same key share a single execution.
+// Nothing is cached once the execution is over.
+func SingleFlight[K comparable, A any](f func(K) warp.Result[A]) func(K) warp.Result[A] {
+	var (
+		mu      sync.Mutex
+		flights = make(map[K]*flight[A])
+	)
+
+	return func(k K) warp.Result[A] {
+		return func(ctx context.Context) (a A, err error) {
+			for {
+				mu.Lock()
+				fl, ok := flights[k]
+				if !ok {
+					fl = newFlight[A]()
+					flights[k] = fl
+					go func() {
+						fl.run(ctx, Recover(f(k)))
+						mu.Lock()
+						delete(flights, k)
+						mu.Unlock()
+						close(fl.done)
+					}()
+				}
+				mu.Unlock()
+
+				if a, err = fl.wait(ctx); !fl.abandoned(ctx) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// A flight is an execution of a result which may be awaited by many callers.
+type flight[A any] struct {
+	done      chan struct{}
+	a         A
+	err       error
+	cancelled bool
+}
+
+func newFlight[A any]() *flight[A] {
+	return &flight[A]{done: make(chan struct{})}
+}
+
+func (f *flight[A]) run(ctx context.Context, ma warp.Result[A]) {
+	f.a, f.err = ma(ctx)
+	f.cancelled = ctx != nil && ctx.Err() != nil
+}
+
+// wait waits for the flight to land, or for the context to be cancelled.
+func (f *flight[A]) wait(ctx context.Context) (a A, err error) {
+	var done <-chan struct{}
+
+	if ctx != nil {
+		done = ctx.Done()
+	}
+
+	select {
+	case <-f.done:
+		return f.a, f.err
+	case <-done:
+		return a, ctx.Err()
+	}
+}
+
+// abandoned reports whether the flight has been cancelled through the context of
+// another caller, in which case a caller whose context is still alive should try
+// again.
+func (f *flight[A]) abandoned(ctx context.Context) bool {
+	select {
+	case <-f.done:
+	default:
+		return false
+	}
+	return f.cancelled && (ctx == nil || ctx.Err() == nil)
+}
+
+// A cache holds the latest value of a result along with its expiry time, and the
+// flight which is currently refreshing it, if any.
+type cache[A any] struct {
+	ma      warp.Result[A]
+	ttl     time.Duration
+	config  cacheConfig
+	mu      sync.Mutex
+	value   *flight[A]
+	expires time.Time
+	call    *flight[A]
+}
+
+// get returns the flight holding the cached value if it has not expired, or the
+// flight which is fetching a new value otherwise, starting one if needed. It
+// starts a background refresh if the cached value is about to expire.
+func (c *cache[A]) get(ctx context.Context) *flight[A] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := clock.FromContext(ctx).Now()
+
+	if c.value != nil && (c.ttl <= 0 || now.Before(c.expires)) {
+		if c.ttl > 0 && c.config.refreshAhead > 0 && c.call == nil &&
+			!now.Before(c.expires.Add(-c.config.refreshAhead)) {
+			c.fetch(flow.Detach(ctx))
+		}
+		return c.value
+	}
+
+	if c.call == nil {
+		c.fetch(ctx)
+	}
+
+	return c.call
+}
+
+// fetch starts a new flight which runs the result and caches its outcome.
+func (c *cache[A]) fetch(ctx context.Context) {
+	f := newFlight[A]()
+
+	c.call = f
+
+	go func() {
+		f.run(ctx, c.ma)
+
+		c.mu.Lock()
+		c.call = nil
+		if f.err == nil || (c.config.cacheErrors && !f.cancelled && !isContextError(f.err)) {
+			c.value = f
+			c.expires = clock.FromContext(ctx).Now().Add(c.ttl)
+		}
+		c.mu.Unlock()
+
+		close(f.done)
+	}()
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,44 @@
+package result
+
+import (
+	"github.com/onur1/warp"
+)
+
+// Do creates a result which succeeds with an initial scope, usually the zero value
+// of a struct with a field for each intermediate value of a workflow. The scope is
+// filled in by the steps of the workflow using Bind, Let and ApS, and the final
+// value is computed from it using Return.
+func Do[S any](s S) warp.Result[S] {
+	return Ok(s)
+}
+
+// Bind creates a result which runs a result determined by the scope of a
+// succeeding result, and stores its value in a copy of the scope using a setter.
+func Bind[S, A any](ms warp.Result[S], f func(S) warp.Result[A], set func(*S, A)) warp.Result[S] {
+	return Chain(ms, func(s S) warp.Result[S] {
+		return Map(f(s), func(a A) S {
+			set(&s, a)
+			return s
+		})
+	})
+}
+
+// Let is like Bind but it stores the return value of a pure function.
+func Let[S, A any](ms warp.Result[S], f func(S) A, set func(*S, A)) warp.Result[S] {
+	return Map(ms, func(s S) S {
+		set(&s, f(s))
+		return s
+	})
+}
+
+// ApS is like Bind but it stores the value of a result which doesn't depend on
+// the scope.
+func ApS[S, A any](ms warp.Result[S], fa warp.Result[A], set func(*S, A)) warp.Result[S] {
+	return Bind(ms, func(S) warp.Result[A] { return fa }, set)
+}
+
+// Return creates a result by computing a value from the scope of a succeeding
+// result.
+func Return[S, A any](ms warp.Result[S], f func(S) A) warp.Result[A] {
+	return Map(ms, f)
+}
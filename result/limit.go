@@ -0,0 +1,26 @@
+package result
+
+import (
+	"context"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/limiter"
+)
+
+// Limit creates a result which runs a result once a limiter lets it through,
+// holding on to what it has acquired from the limiter until the result finishes.
+// It fails with the error of the limiter, or with the error of the context if it
+// is cancelled while waiting.
+func Limit[A any](ma warp.Result[A], l limiter.Limiter) warp.Result[A] {
+	return func(ctx context.Context) (a A, err error) {
+		var release func()
+
+		if release, err = l.Acquire(ctx); err != nil {
+			return
+		}
+
+		defer release()
+
+		return ma(ctx)
+	}
+}
@@ -0,0 +1,184 @@
+package result
+
+import (
+	"context"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/internal/flow"
+)
+
+// ApPar is like Ap but it runs both results concurrently, failing with the first
+// error and cancelling the other result.
+func ApPar[A, B any](fab warp.Result[func(A) B], fa warp.Result[A]) warp.Result[B] {
+	return Map2(fab, fa, func(ab func(A) B, a A) B {
+		return ab(a)
+	})
+}
+
+// Map2 creates a result by running two results concurrently and combining their
+// values with a function. It fails with the first error, cancelling the results
+// which are still running.
+func Map2[A, B, C any](fa warp.Result[A], fb warp.Result[B], fn func(A, B) C) warp.Result[C] {
+	return func(ctx context.Context) (_ C, err error) {
+		var (
+			a A
+			b B
+		)
+
+		if err = parallel(ctx, set(fa, &a), set(fb, &b)); err != nil {
+			return
+		}
+
+		return fn(a, b), nil
+	}
+}
+
+// Map3 is like Map2 but it combines the values of three results.
+func Map3[A, B, C, D any](fa warp.Result[A], fb warp.Result[B], fc warp.Result[C], fn func(A, B, C) D) warp.Result[D] {
+	return func(ctx context.Context) (_ D, err error) {
+		var (
+			a A
+			b B
+			c C
+		)
+
+		if err = parallel(ctx, set(fa, &a), set(fb, &b), set(fc, &c)); err != nil {
+			return
+		}
+
+		return fn(a, b, c), nil
+	}
+}
+
+// Map4 is like Map2 but it combines the values of four results.
+func Map4[A, B, C, D, E any](fa warp.Result[A], fb warp.Result[B], fc warp.Result[C], fd warp.Result[D], fn func(A, B, C, D) E) warp.Result[E] {
+	return func(ctx context.Context) (_ E, err error) {
+		var (
+			a A
+			b B
+			c C
+			d D
+		)
+
+		if err = parallel(ctx, set(fa, &a), set(fb, &b), set(fc, &c), set(fd, &d)); err != nil {
+			return
+		}
+
+		return fn(a, b, c, d), nil
+	}
+}
+
+// Map5 is like Map2 but it combines the values of five results.
+func Map5[A, B, C, D, E, F any](fa warp.Result[A], fb warp.Result[B], fc warp.Result[C], fd warp.Result[D], fe warp.Result[E], fn func(A, B, C, D, E) F) warp.Result[F] {
+	return func(ctx context.Context) (_ F, err error) {
+		var (
+			a A
+			b B
+			c C
+			d D
+			e E
+		)
+
+		if err = parallel(ctx, set(fa, &a), set(fb, &b), set(fc, &c), set(fd, &d), set(fe, &e)); err != nil {
+			return
+		}
+
+		return fn(a, b, c, d, e), nil
+	}
+}
+
+// Map6 is like Map2 but it combines the values of six results.
+func Map6[A, B, C, D, E, F, G any](fa warp.Result[A], fb warp.Result[B], fc warp.Result[C], fd warp.Result[D], fe warp.Result[E], ff warp.Result[F], fn func(A, B, C, D, E, F) G) warp.Result[G] {
+	return func(ctx context.Context) (_ G, err error) {
+		var (
+			a A
+			b B
+			c C
+			d D
+			e E
+			f F
+		)
+
+		if err = parallel(ctx, set(fa, &a), set(fb, &b), set(fc, &c), set(fd, &d), set(fe, &e), set(ff, &f)); err != nil {
+			return
+		}
+
+		return fn(a, b, c, d, e, f), nil
+	}
+}
+
+// Map7 is like Map2 but it combines the values of seven results.
+func Map7[A, B, C, D, E, F, G, H any](fa warp.Result[A], fb warp.Result[B], fc warp.Result[C], fd warp.Result[D], fe warp.Result[E], ff warp.Result[F], fg warp.Result[G], fn func(A, B, C, D, E, F, G) H) warp.Result[H] {
+	return func(ctx context.Context) (_ H, err error) {
+		var (
+			a A
+			b B
+			c C
+			d D
+			e E
+			f F
+			g G
+		)
+
+		if err = parallel(ctx, set(fa, &a), set(fb, &b), set(fc, &c), set(fd, &d), set(fe, &e), set(ff, &f), set(fg, &g)); err != nil {
+			return
+		}
+
+		return fn(a, b, c, d, e, f, g), nil
+	}
+}
+
+// Map8 is like Map2 but it combines the values of eight results.
+func Map8[A, B, C, D, E, F, G, H, I any](fa warp.Result[A], fb warp.Result[B], fc warp.Result[C], fd warp.Result[D], fe warp.Result[E], ff warp.Result[F], fg warp.Result[G], fh warp.Result[H], fn func(A, B, C, D, E, F, G, H) I) warp.Result[I] {
+	return func(ctx context.Context) (_ I, err error) {
+		var (
+			a A
+			b B
+			c C
+			d D
+			e E
+			f F
+			g G
+			h H
+		)
+
+		if err = parallel(ctx, set(fa, &a), set(fb, &b), set(fc, &c), set(fd, &d), set(fe, &e), set(ff, &f), set(fg, &g), set(fh, &h)); err != nil {
+			return
+		}
+
+		return fn(a, b, c, d, e, f, g, h), nil
+	}
+}
+
+// set returns a function which runs a result and stores its value in a, failing
+// with a *PanicError if the result panics.
+func set[A any](fa warp.Result[A], a *A) func(context.Context) error {
+	return func(ctx context.Context) (err error) {
+		defer protect(&err)
+		*a, err = fa(ctx)
+		return
+	}
+}
+
+// parallel runs functions concurrently with a shared context, returning the first
+// error and cancelling the context of the functions which are still running.
+func parallel(ctx context.Context, fs ...func(context.Context) error) error {
+	ctx, cancel := flow.WithCancel(ctx)
+	defer cancel()
+
+	errc := make(chan error, len(fs))
+
+	for _, f := range fs {
+		go func(f func(context.Context) error) {
+			errc <- f(ctx)
+		}(f)
+	}
+
+	for range fs {
+		if err := <-errc; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package result
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/internal/flow"
+)
+
+// ErrNoResults is the error of a result which races an empty slice of results.
+var ErrNoResults = errors.New("no results")
+
+// A Settled represents the outcome of a result which is either a value of type A,
+// or an error.
+type Settled[A any] struct {
+	Value A
+	Err   error
+}
+
+// All creates a result which runs a slice of results concurrently and returns
+// their values in order, running at most limit of them at a time; a limit which
+// is not positive means no limit. It fails with the first error, cancelling the
+// results which are still running.
+func All[A any](mas []warp.Result[A], limit int) warp.Result[[]A] {
+	return func(ctx context.Context) (_ []A, err error) {
+		ctx, cancel := flow.WithCancel(ctx)
+		defer cancel()
+
+		var (
+			as = make([]A, len(mas))
+			os = spawn(ctx, mas, limit)
+		)
+
+		for i := 0; i < len(mas); i++ {
+			o, ok := <-os
+			if !ok {
+				return nil, ctx.Err()
+			}
+			if o.err != nil {
+				return nil, o.err
+			}
+			as[o.index] = o.a
+		}
+
+		return as, nil
+	}
+}
+
+// AllSettled creates a result which runs a slice of results concurrently and
+// returns all of their outcomes in order, running at most limit of them at a time;
+// a limit which is not positive means no limit. It fails only with the error of
+// the context if it is cancelled before every result has finished.
+func AllSettled[A any](mas []warp.Result[A], limit int) warp.Result[[]Settled[A]] {
+	return func(ctx context.Context) (_ []Settled[A], err error) {
+		ctx, cancel := flow.WithCancel(ctx)
+		defer cancel()
+
+		var (
+			ss = make([]Settled[A], len(mas))
+			os = spawn(ctx, mas, limit)
+		)
+
+		for i := 0; i < len(mas); i++ {
+			o, ok := <-os
+			if !ok {
+				return nil, ctx.Err()
+			}
+			ss[o.index] = Settled[A]{Value: o.a, Err: o.err}
+		}
+
+		return ss, nil
+	}
+}
+
+// Race creates a result which runs a slice of results concurrently and returns
+// the outcome of the first one to finish, cancelling the others. It fails with
+// ErrNoResults if the slice is empty.
+func Race[A any](mas []warp.Result[A]) warp.Result[A] {
+	return func(ctx context.Context) (a A, err error) {
+		if len(mas) == 0 {
+			return a, ErrNoResults
+		}
+
+		ctx, cancel := flow.WithCancel(ctx)
+		defer cancel()
+
+		o, ok := <-spawn(ctx, mas, 0)
+		if !ok {
+			return a, ctx.Err()
+		}
+
+		return o.a, o.err
+	}
+}
+
+// Any creates a result which runs a slice of results concurrently and returns the
+// value of the first one to succeed, cancelling the others, while running at most
+// limit of them at a time; a limit which is not positive means no limit. If all of
+// them fail, it fails with their errors joined in order. It fails with ErrNoResults
+// if the slice is empty.
+func Any[A any](mas []warp.Result[A], limit int) warp.Result[A] {
+	return func(ctx context.Context) (a A, err error) {
+		if len(mas) == 0 {
+			return a, ErrNoResults
+		}
+
+		ctx, cancel := flow.WithCancel(ctx)
+		defer cancel()
+
+		var (
+			errs = make([]error, len(mas))
+			os   = spawn(ctx, mas, limit)
+		)
+
+		for i := 0; i < len(mas); i++ {
+			o, ok := <-os
+			if !ok {
+				return a, ctx.Err()
+			}
+			if o.err == nil {
+				return o.a, nil
+			}
+			errs[o.index] = o.err
+		}
+
+		return a, errors.Join(errs...)
+	}
+}
+
+type outcome[A any] struct {
+	index int
+	a     A
+	err   error
+}
+
+// spawn runs a slice of results concurrently, at most limit of them at a time, and
+// sends their outcomes to a channel which is closed once every result that has
+// been started has finished. No more results are started after the context is
+// cancelled.
+func spawn[A any](ctx context.Context, mas []warp.Result[A], limit int) <-chan outcome[A] {
+	var (
+		os  = make(chan outcome[A], len(mas))
+		sem chan struct{}
+	)
+
+	if limit > 0 {
+		sem = make(chan struct{}, limit)
+	}
+
+	go func() {
+		var wg sync.WaitGroup
+
+		defer func() {
+			wg.Wait()
+			close(os)
+		}()
+
+		for i, ma := range mas {
+			if sem != nil {
+				select {
+				case <-ctx.Done():
+					return
+				case sem <- struct{}{}:
+				}
+			} else if ctx.Err() != nil {
+				return
+			}
+
+			wg.Add(1)
+
+			go func(i int, ma warp.Result[A]) {
+				defer wg.Done()
+
+				a, err := Recover(ma)(ctx)
+
+				if sem != nil {
+					<-sem
+				}
+
+				os <- outcome[A]{index: i, a: a, err: err}
+			}(i, ma)
+		}
+	}()
+
+	return os
+}
@@ -0,0 +1,60 @@
+package result
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/onur1/warp"
+)
+
+// A PanicError represents a panic which has been recovered from, along with the
+// stack trace of the goroutine at the time of the panic.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+// NewPanicError creates a PanicError from a value returned by recover, capturing
+// the stack trace of the current goroutine. It is meant to be called from a
+// deferred function.
+func NewPanicError(v any) *PanicError {
+	return &PanicError{Value: v, Stack: debug.Stack()}
+}
+
+func (err *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", err.Value)
+}
+
+// Unwrap returns the value of the panic if it is an error.
+func (err *PanicError) Unwrap() error {
+	if e, ok := err.Value.(error); ok {
+		return e
+	}
+	return nil
+}
+
+// Try creates a result from a function which returns a (value, error) pair,
+// failing with a *PanicError if the function panics.
+func Try[A any](f func() (A, error)) warp.Result[A] {
+	return func(_ context.Context) (a A, err error) {
+		defer protect(&err)
+		return f()
+	}
+}
+
+// Recover creates a result which fails with a *PanicError if a result panics.
+func Recover[A any](ma warp.Result[A]) warp.Result[A] {
+	return func(ctx context.Context) (a A, err error) {
+		defer protect(&err)
+		return ma(ctx)
+	}
+}
+
+// protect recovers from a panic, storing it in err as a *PanicError. It must be
+// deferred directly.
+func protect(err *error) {
+	if r := recover(); r != nil {
+		*err = NewPanicError(r)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/onur1/warp"
+	"github.com/onur1/warp/clock"
+	"github.com/onur1/warp/internal/flow"
 )
 
 // Ok creates a result which never fails and returns a value of type A.
@@ -15,16 +17,26 @@ func Ok[A any](a A) warp.Result[A] {
 	}
 }
 
+// After creates a result which returns a value after waiting for the specified
+// duration on the clock carried by the context, or fails with the error of the
+// context if it is cancelled first.
 func After[A any](dur time.Duration, a A) warp.Result[A] {
-	return func(_ context.Context) (A, error) {
-		time.Sleep(dur)
+	return func(ctx context.Context) (_ A, err error) {
+		if err = flow.Sleep(ctx, clock.FromContext(ctx), dur); err != nil {
+			return
+		}
 		return a, nil
 	}
 }
 
+// ErrorAfter creates a result which fails with an error after waiting for the
+// specified duration on the clock carried by the context, or with the error of
+// the context if it is cancelled first.
 func ErrorAfter[A any](dur time.Duration, err error) warp.Result[A] {
-	return func(_ context.Context) (a A, _ error) {
-		time.Sleep(dur)
+	return func(ctx context.Context) (a A, _ error) {
+		if e := flow.Sleep(ctx, clock.FromContext(ctx), dur); e != nil {
+			return a, e
+		}
 		return a, err
 	}
 }
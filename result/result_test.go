@@ -4,9 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/onur1/warp"
+	"github.com/onur1/warp/clock"
+	"github.com/onur1/warp/clock/clocktest"
+	"github.com/onur1/warp/limiter"
 	"github.com/onur1/warp/nilable"
 	"github.com/onur1/warp/result"
 	"github.com/stretchr/testify/assert"
@@ -176,6 +182,44 @@ func TestResult(t *testing.T) {
 			),
 			expected: 42,
 		},
+		{
+			desc:     "ApPar",
+			result:   result.ApPar(result.Ok(double), result.Ok(42)),
+			expected: 84,
+		},
+		{
+			desc:        "ApPar (error)",
+			result:      result.ApPar(result.After(time.Hour, double), result.Error[int](errFailed)),
+			expectedErr: errFailed,
+		},
+		{
+			desc:     "Map2",
+			result:   result.Map2(result.Ok(1), result.After(time.Millisecond, 2), add),
+			expected: 3,
+		},
+		{
+			desc:        "Map2 (error)",
+			result:      result.Map2(result.After(time.Hour, 1), result.Error[int](errFailed), add),
+			expectedErr: errFailed,
+		},
+		{
+			desc: "Map3",
+			result: result.Map3(result.Ok(1), result.Ok(2), result.Ok(3), func(a, b, c int) int {
+				return a + b + c
+			}),
+			expected: 6,
+		},
+		{
+			desc: "Map8",
+			result: result.Map8(
+				result.Ok(1), result.Ok(2), result.Ok(3), result.Ok(4),
+				result.Ok(5), result.Ok(6), result.Ok(7), result.Ok(8),
+				func(a, b, c, d, e, f, g, h int) int {
+					return a + b + c + d + e + f + g + h
+				},
+			),
+			expected: 36,
+		},
 		{
 			desc: "FilterOrElse",
 			result: result.FilterOrElse(result.Ok(42), func(x int) bool {
@@ -202,6 +246,419 @@ func TestResult(t *testing.T) {
 	}
 }
 
+func TestAfter(t *testing.T) {
+	c := clocktest.NewClock(time.Unix(0, 0))
+
+	ctx := clock.WithClock(context.Background(), c)
+
+	r := make(chan int)
+
+	go func() {
+		result.Fork(ctx, result.After(time.Hour, 42), func(error) {}, func(a int) {
+			r <- a
+		})
+	}()
+
+	c.BlockUntil(1)
+	c.Advance(time.Hour)
+
+	assert.Equal(t, 42, <-r)
+}
+
+func TestRetry(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		result      warp.Result[int]
+		expected    int
+		expectedErr error
+	}{
+		{
+			desc:     "Retry",
+			result:   result.Retry(flaky(2, 42), result.MaxAttempts(3)),
+			expected: 42,
+		},
+		{
+			desc:        "Retry (exhausted)",
+			result:      result.Retry(flaky(3, 42), result.MaxAttempts(3)),
+			expectedErr: &result.RetryError{Errors: []error{errFailed, errFailed, errFailed}},
+		},
+		{
+			desc: "Retry (backoff)",
+			result: result.Retry(
+				flaky(2, 42),
+				result.ExponentialBackoff(time.Millisecond).And(result.MaxAttempts(5)),
+			),
+			expected: 42,
+		},
+		{
+			desc: "Retry (not retryable)",
+			result: result.Retry(flaky(2, 42), result.RetryIf(func(err error) bool {
+				return !errors.Is(err, errFailed)
+			})),
+			expectedErr: &result.RetryError{Errors: []error{errFailed}},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			assertEq(t, tC.result, tC.expected, tC.expectedErr)
+		})
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		policy   result.RetryPolicy
+		status   result.RetryStatus
+		expected time.Duration
+		retry    bool
+	}{
+		{
+			desc:     "ConstantBackoff",
+			policy:   result.ConstantBackoff(time.Second),
+			status:   result.RetryStatus{Attempt: 3},
+			expected: time.Second,
+			retry:    true,
+		},
+		{
+			desc:     "ExponentialBackoff",
+			policy:   result.ExponentialBackoff(time.Second),
+			status:   result.RetryStatus{Attempt: 4},
+			expected: time.Second * 8,
+			retry:    true,
+		},
+		{
+			desc:     "ExponentialBackoff (overflow)",
+			policy:   result.ExponentialBackoff(time.Second),
+			status:   result.RetryStatus{Attempt: 100},
+			expected: time.Duration(math.MaxInt64),
+			retry:    true,
+		},
+		{
+			desc:     "CapDelay",
+			policy:   result.CapDelay(result.ExponentialBackoff(time.Second), time.Second*5),
+			status:   result.RetryStatus{Attempt: 4},
+			expected: time.Second * 5,
+			retry:    true,
+		},
+		{
+			desc:   "MaxAttempts",
+			policy: result.MaxAttempts(3),
+			status: result.RetryStatus{Attempt: 3},
+		},
+		{
+			desc:   "MaxElapsed",
+			policy: result.MaxElapsed(time.Second),
+			status: result.RetryStatus{Attempt: 1, Elapsed: time.Second},
+		},
+		{
+			desc:     "And",
+			policy:   result.ConstantBackoff(time.Second).And(result.ConstantBackoff(time.Second * 2)),
+			status:   result.RetryStatus{Attempt: 1},
+			expected: time.Second * 2,
+			retry:    true,
+		},
+		{
+			desc:   "And (stop)",
+			policy: result.ConstantBackoff(time.Second).And(result.MaxAttempts(1)),
+			status: result.RetryStatus{Attempt: 1},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			d, ok := tC.policy(tC.status)
+			assert.Equal(t, tC.retry, ok)
+			if ok {
+				assert.Equal(t, tC.expected, d)
+			}
+		})
+	}
+}
+
+func TestDecorrelatedJitter(t *testing.T) {
+	policy := result.DecorrelatedJitter(time.Second, time.Second*10)
+
+	status := result.RetryStatus{}
+
+	for i := 1; i < 100; i++ {
+		status.Attempt = i
+		d, ok := policy(status)
+		assert.True(t, ok)
+		assert.GreaterOrEqual(t, d, time.Second)
+		assert.LessOrEqual(t, d, time.Second*10)
+		status.Delay = d
+	}
+}
+
+func TestRetryCancel(t *testing.T) {
+	c := clocktest.NewClock(time.Unix(0, 0))
+
+	ctx, cancel := context.WithCancel(clock.WithClock(context.Background(), c))
+
+	errc := make(chan error)
+
+	go func() {
+		_, err := result.Retry(flaky(2, 42), result.ConstantBackoff(time.Hour))(ctx)
+		errc <- err
+	}()
+
+	c.BlockUntil(1)
+
+	cancel()
+
+	err := <-errc
+
+	assert.Equal(t, &result.RetryError{Errors: []error{errFailed}, Err: context.Canceled}, err)
+	assert.ErrorIs(t, err, errFailed)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestTimeout(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		result      warp.Result[int]
+		expected    int
+		expectedErr error
+	}{
+		{
+			desc:     "Timeout",
+			result:   result.Timeout(result.Ok(42), time.Hour),
+			expected: 42,
+		},
+		{
+			desc:        "Timeout (error)",
+			result:      result.Timeout(result.Error[int](errFailed), time.Hour),
+			expectedErr: errFailed,
+		},
+		{
+			desc:        "Timeout (timeout)",
+			result:      result.Timeout(result.After(time.Hour, 42), time.Millisecond),
+			expectedErr: result.ErrTimeout,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			assertEq(t, tC.result, tC.expected, tC.expectedErr)
+		})
+	}
+}
+
+func TestTimeoutCancel(t *testing.T) {
+	cancelled := make(chan error)
+
+	_, err := result.Timeout(func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		cancelled <- ctx.Err()
+		return 0, ctx.Err()
+	}, time.Millisecond)(context.Background())
+
+	assert.Equal(t, result.ErrTimeout, err)
+	assert.Equal(t, context.Canceled, <-cancelled)
+}
+
+func TestAfterCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := result.After(time.Hour, 42)(ctx)
+	assert.Equal(t, context.Canceled, err)
+
+	_, err = result.ErrorAfter[int](time.Hour, errFailed)(ctx)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestParallel(t *testing.T) {
+	errSecond := errors.New("second")
+
+	testCases := []struct {
+		desc        string
+		result      warp.Result[any]
+		expected    any
+		expectedErr error
+	}{
+		{
+			desc:     "All",
+			result:   boxed(result.All([]warp.Result[int]{result.Ok(1), result.After(time.Millisecond, 2), result.Ok(3)}, 0)),
+			expected: []int{1, 2, 3},
+		},
+		{
+			desc:     "All (limit)",
+			result:   boxed(result.All([]warp.Result[int]{result.After(time.Millisecond, 1), result.Ok(2), result.Ok(3)}, 1)),
+			expected: []int{1, 2, 3},
+		},
+		{
+			desc:     "All (empty)",
+			result:   boxed(result.All([]warp.Result[int]{}, 0)),
+			expected: []int{},
+		},
+		{
+			desc:        "All (error)",
+			result:      boxed(result.All([]warp.Result[int]{result.After(time.Hour, 1), result.Error[int](errFailed)}, 0)),
+			expectedErr: errFailed,
+		},
+		{
+			desc: "AllSettled",
+			result: boxed(result.AllSettled([]warp.Result[int]{
+				result.After(time.Millisecond, 1),
+				result.Error[int](errFailed),
+			}, 0)),
+			expected: []result.Settled[int]{{Value: 1}, {Err: errFailed}},
+		},
+		{
+			desc:     "Race",
+			result:   boxed(result.Race([]warp.Result[int]{result.After(time.Hour, 1), result.After(time.Millisecond, 2)})),
+			expected: 2,
+		},
+		{
+			desc:        "Race (error)",
+			result:      boxed(result.Race([]warp.Result[int]{result.After(time.Hour, 1), result.Error[int](errFailed)})),
+			expectedErr: errFailed,
+		},
+		{
+			desc:        "Race (empty)",
+			result:      boxed(result.Race([]warp.Result[int]{})),
+			expectedErr: result.ErrNoResults,
+		},
+		{
+			desc: "Any",
+			result: boxed(result.Any([]warp.Result[int]{
+				result.Error[int](errFailed),
+				result.After(time.Millisecond, 2),
+				result.After(time.Hour, 3),
+			}, 0)),
+			expected: 2,
+		},
+		{
+			desc: "Any (error)",
+			result: boxed(result.Any([]warp.Result[int]{
+				result.ErrorAfter[int](time.Millisecond, errFailed),
+				result.Error[int](errSecond),
+			}, 0)),
+			expectedErr: errors.Join(errFailed, errSecond),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			x, err := tC.result(context.TODO())
+			assert.Equal(t, tC.expectedErr, err)
+			assert.Equal(t, tC.expected, x)
+		})
+	}
+}
+
+func TestTraverse(t *testing.T) {
+	inverse := func(n int) warp.Result[float64] {
+		if n == 0 {
+			return result.Error[float64](errFailed)
+		}
+		return result.Ok(1 / float64(n))
+	}
+
+	inverseAt := func(_ string, n int) warp.Result[float64] {
+		return inverse(n)
+	}
+
+	testCases := []struct {
+		desc        string
+		result      warp.Result[any]
+		expected    any
+		expectedErr error
+	}{
+		{
+			desc:     "Sequence",
+			result:   boxed(result.Sequence([]warp.Result[int]{result.Ok(1), result.Ok(2)})),
+			expected: []int{1, 2},
+		},
+		{
+			desc:        "Sequence (error)",
+			result:      boxed(result.Sequence([]warp.Result[int]{result.Ok(1), result.Error[int](errFailed)})),
+			expectedErr: errFailed,
+		},
+		{
+			desc:     "Traverse",
+			result:   boxed(result.Traverse([]int{1, 2, 4}, inverse)),
+			expected: []float64{1, 0.5, 0.25},
+		},
+		{
+			desc:        "Traverse (error)",
+			result:      boxed(result.Traverse([]int{1, 0, 4}, inverse)),
+			expectedErr: errFailed,
+		},
+		{
+			desc:     "TraverseMap",
+			result:   boxed(result.TraverseMap(map[string]int{"a": 1, "b": 2}, inverseAt)),
+			expected: map[string]float64{"a": 1, "b": 0.5},
+		},
+		{
+			desc:        "TraverseMap (error)",
+			result:      boxed(result.TraverseMap(map[string]int{"a": 1, "b": 0}, inverseAt)),
+			expectedErr: errFailed,
+		},
+		{
+			desc:     "SequencePar",
+			result:   boxed(result.SequencePar([]warp.Result[int]{result.After(time.Millisecond, 1), result.Ok(2)}, 2)),
+			expected: []int{1, 2},
+		},
+		{
+			desc:     "TraversePar",
+			result:   boxed(result.TraversePar([]int{1, 2, 4}, inverse, 2)),
+			expected: []float64{1, 0.5, 0.25},
+		},
+		{
+			desc:        "TraversePar (error)",
+			result:      boxed(result.TraversePar([]int{1, 0, 4}, inverse, 2)),
+			expectedErr: errFailed,
+		},
+		{
+			desc:     "TraverseMapPar",
+			result:   boxed(result.TraverseMapPar(map[string]int{"a": 1, "b": 2, "c": 4}, inverseAt, 2)),
+			expected: map[string]float64{"a": 1, "b": 0.5, "c": 0.25},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			x, err := tC.result(context.TODO())
+			assert.Equal(t, tC.expectedErr, err)
+			assert.Equal(t, tC.expected, x)
+		})
+	}
+}
+
+func TestMap2Concurrent(t *testing.T) {
+	fa, fb := rendezvous(1, 2)
+
+	n, err := result.Timeout(result.Map2(fa, fb, add), time.Second)(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+}
+
+func TestParallelLimit(t *testing.T) {
+	var running, peak int32
+
+	mas := make([]warp.Result[int], 10)
+
+	for i := range mas {
+		mas[i] = func(ctx context.Context) (int, error) {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			defer atomic.AddInt32(&running, -1)
+			return result.After(time.Millisecond, 1)(ctx)
+		}
+	}
+
+	as, err := result.All(mas, 3)(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Len(t, as, 10)
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(3))
+}
+
 func assertEq(t *testing.T, res warp.Result[int], expected int, expectedErr error) {
 	x, err := res(context.TODO())
 	if err != nil {
@@ -211,6 +668,457 @@ func assertEq(t *testing.T, res warp.Result[int], expected int, expectedErr erro
 	}
 }
 
+func TestDo(t *testing.T) {
+	type key struct{}
+
+	type scope struct {
+		user   string
+		id     int
+		orders []int
+		total  int
+	}
+
+	var calls int32
+
+	lookup := func(ctx context.Context) (string, error) {
+		return ctx.Value(key{}).(string), nil
+	}
+
+	workflow := func(orders warp.Result[[]int]) warp.Result[string] {
+		ma := result.Do(scope{})
+		ma = result.Bind(ma, func(scope) warp.Result[string] {
+			return lookup
+		}, func(s *scope, user string) { s.user = user })
+		ma = result.Let(ma, func(s scope) int {
+			return len(s.user)
+		}, func(s *scope, id int) { s.id = id })
+		ma = result.ApS(ma, orders, func(s *scope, orders []int) { s.orders = orders })
+		ma = result.Bind(ma, func(s scope) warp.Result[int] {
+			atomic.AddInt32(&calls, 1)
+			total := s.id
+			for _, n := range s.orders {
+				total += n
+			}
+			return result.Ok(total)
+		}, func(s *scope, total int) { s.total = total })
+		return result.Return(ma, func(s scope) string {
+			return fmt.Sprintf("%s:%d", s.user, s.total)
+		})
+	}
+
+	ctx := context.WithValue(context.TODO(), key{}, "bob")
+
+	s, err := workflow(result.Ok([]int{1, 2, 3}))(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "bob:9", s)
+	assert.Equal(t, int32(1), calls)
+
+	_, err = workflow(result.Error[[]int](errFailed))(ctx)
+	assert.Equal(t, errFailed, err)
+	assert.Equal(t, int32(1), calls)
+}
+
+func TestBracket(t *testing.T) {
+	errRelease := errors.New("release")
+
+	testCases := []struct {
+		desc        string
+		acquire     warp.Result[int]
+		use         func(int) warp.Result[int]
+		release     error
+		expected    int
+		cancelled   bool
+		expectedErr []error
+		released    int32
+	}{
+		{
+			desc:     "succeed",
+			acquire:  result.Ok(21),
+			use:      func(n int) warp.Result[int] { return result.Ok(double(n)) },
+			expected: 42,
+			released: 1,
+		},
+		{
+			desc:        "use fails",
+			acquire:     result.Ok(21),
+			use:         func(int) warp.Result[int] { return result.Error[int](errFailed) },
+			expectedErr: []error{errFailed},
+			released:    1,
+		},
+		{
+			desc:        "release fails",
+			acquire:     result.Ok(21),
+			use:         func(n int) warp.Result[int] { return result.Ok(double(n)) },
+			release:     errRelease,
+			expected:    42,
+			expectedErr: []error{errRelease},
+			released:    1,
+		},
+		{
+			desc:        "both fail",
+			acquire:     result.Ok(21),
+			use:         func(int) warp.Result[int] { return result.Error[int](errFailed) },
+			release:     errRelease,
+			expectedErr: []error{errFailed, errRelease},
+			released:    1,
+		},
+		{
+			desc:        "acquire fails",
+			acquire:     result.Error[int](errFailed),
+			use:         func(n int) warp.Result[int] { return result.Ok(n) },
+			expectedErr: []error{errFailed},
+		},
+		{
+			desc:    "cancelled",
+			acquire: result.Ok(21),
+			use: func(int) warp.Result[int] {
+				return result.After(time.Hour, 0)
+			},
+			cancelled:   true,
+			expectedErr: []error{context.Canceled},
+			released:    1,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var released int32
+
+			ma := result.Bracket(tC.acquire, tC.use, func(int) error {
+				atomic.AddInt32(&released, 1)
+				return tC.release
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tC.cancelled {
+				cancel()
+			}
+			defer cancel()
+
+			n, err := ma(ctx)
+
+			assert.Equal(t, tC.expected, n)
+			for _, expectedErr := range tC.expectedErr {
+				assert.ErrorIs(t, err, expectedErr)
+			}
+			if tC.expectedErr == nil {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tC.released, released)
+		})
+	}
+
+	t.Run("panic", func(t *testing.T) {
+		var released int32
+
+		ma := result.Bracket(result.Ok(21), func(int) warp.Result[int] {
+			panic("boom")
+		}, func(int) error {
+			atomic.AddInt32(&released, 1)
+			return nil
+		})
+
+		assert.PanicsWithValue(t, "boom", func() {
+			_, _ = ma(context.TODO())
+		})
+		assert.Equal(t, int32(1), released)
+	})
+}
+
+func TestRecover(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	testCases := []struct {
+		desc     string
+		result   warp.Result[int]
+		expected any
+	}{
+		{
+			desc:     "Try",
+			result:   result.Try(func() (int, error) { panic("boom") }),
+			expected: "boom",
+		},
+		{
+			desc:     "Recover",
+			result:   result.Recover(func(context.Context) (int, error) { panic(errBoom) }),
+			expected: errBoom,
+		},
+		{
+			desc:     "Map2",
+			result:   result.Map2(result.Ok(1), func(context.Context) (int, error) { panic("boom") }, add),
+			expected: "boom",
+		},
+		{
+			desc:     "All",
+			result:   boxedInts(result.All([]warp.Result[int]{result.Ok(1), func(context.Context) (int, error) { panic("boom") }}, 0)),
+			expected: "boom",
+		},
+		{
+			desc:     "Timeout",
+			result:   result.Timeout(func(context.Context) (int, error) { panic("boom") }, time.Second),
+			expected: "boom",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			_, err := tC.result(context.TODO())
+
+			var perr *result.PanicError
+			assert.True(t, errors.As(err, &perr))
+			assert.Equal(t, tC.expected, perr.Value)
+			assert.Contains(t, string(perr.Stack), "result_test.go")
+		})
+	}
+
+	n, err := result.Try(func() (int, error) { return 42, nil })(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, 42, n)
+
+	_, err = result.Recover(func(context.Context) (int, error) { panic(errBoom) })(context.TODO())
+	assert.ErrorIs(t, err, errBoom)
+	assert.EqualError(t, err, "panic: boom")
+}
+
+func TestCached(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		result   func(warp.Result[int]) warp.Result[int]
+		source   warp.Result[int]
+		expected []int
+		errs     int
+		calls    int32
+	}{
+		{
+			desc:     "Memoize",
+			result:   func(ma warp.Result[int]) warp.Result[int] { return result.Memoize(ma) },
+			source:   result.Ok(42),
+			expected: []int{42, 42, 42},
+			calls:    1,
+		},
+		{
+			desc:     "Memoize (error)",
+			result:   func(ma warp.Result[int]) warp.Result[int] { return result.Memoize(ma) },
+			source:   flaky(1, 42),
+			expected: []int{0, 42, 42},
+			errs:     1,
+			calls:    2,
+		},
+		{
+			desc:     "Memoize (CacheErrors)",
+			result:   func(ma warp.Result[int]) warp.Result[int] { return result.Memoize(ma, result.CacheErrors()) },
+			source:   flaky(1, 42),
+			expected: []int{0, 0, 0},
+			errs:     3,
+			calls:    1,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var calls int32
+
+			ma := tC.result(func(ctx context.Context) (int, error) {
+				atomic.AddInt32(&calls, 1)
+				return tC.source(ctx)
+			})
+
+			var (
+				ns   []int
+				errs int
+			)
+
+			for range tC.expected {
+				n, err := ma(context.TODO())
+				if err != nil {
+					errs++
+				}
+				ns = append(ns, n)
+			}
+
+			assert.Equal(t, tC.expected, ns)
+			assert.Equal(t, tC.errs, errs)
+			assert.Equal(t, tC.calls, calls)
+		})
+	}
+}
+
+func TestCachedTTL(t *testing.T) {
+	var (
+		c     = clocktest.NewClock(time.Unix(0, 0))
+		ctx   = clock.WithClock(context.Background(), c)
+		calls int32
+	)
+
+	counter := func(context.Context) (int, error) {
+		return int(atomic.AddInt32(&calls, 1)), nil
+	}
+
+	ma := result.Cached(counter, time.Hour, result.RefreshAhead(10*time.Minute))
+
+	get := func() int {
+		n, err := ma(ctx)
+		assert.NoError(t, err)
+		return n
+	}
+
+	assert.Equal(t, 1, get())
+
+	c.Advance(30 * time.Minute)
+	assert.Equal(t, 1, get())
+
+	c.Advance(25 * time.Minute)
+	assert.Equal(t, 1, get())
+	assert.Eventually(t, func() bool {
+		return get() == 2
+	}, time.Second, time.Millisecond)
+
+	c.Advance(time.Hour)
+	assert.Equal(t, 3, get())
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestSingleFlight(t *testing.T) {
+	var (
+		calls int32
+		gate  = make(chan struct{})
+	)
+
+	sf := result.SingleFlight(func(k string) warp.Result[string] {
+		return func(ctx context.Context) (string, error) {
+			atomic.AddInt32(&calls, 1)
+			select {
+			case <-gate:
+				return k + k, nil
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+		}
+	})
+
+	leader, cancel := context.WithCancel(context.Background())
+
+	errc := make(chan error)
+
+	go func() {
+		_, err := sf("a")(leader)
+		errc <- err
+	}()
+
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&calls) == 1
+	}, time.Second, time.Millisecond)
+
+	rs := make(chan string, 3)
+
+	for i := 0; i < 3; i++ {
+		go func() {
+			s, _ := sf("a")(context.Background())
+			rs <- s
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+
+	cancel()
+
+	assert.Equal(t, context.Canceled, <-errc)
+
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&calls) == 2
+	}, time.Second, time.Millisecond)
+
+	close(gate)
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, "aa", <-rs)
+	}
+
+	s, err := sf("b")(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "bb", s)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestLimit(t *testing.T) {
+	var (
+		l             = limiter.NewSemaphore(2)
+		running, peak int32
+		mas           = make([]warp.Result[int], 10)
+	)
+
+	for i := range mas {
+		mas[i] = result.Limit(func(ctx context.Context) (int, error) {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return 1, nil
+		}, l)
+	}
+
+	ns, err := result.All(mas, 0)(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Len(t, ns, 10)
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = result.Limit(result.Ok(1), l)(ctx)
+	assert.Equal(t, context.Canceled, err)
+}
+
+// rendezvous creates a pair of results which only succeed if they run
+// concurrently, as each of them waits for the other one to start.
+func rendezvous(a, b int) (warp.Result[int], warp.Result[int]) {
+	x, y := make(chan struct{}), make(chan struct{})
+	wait := func(started, other chan struct{}, n int) warp.Result[int] {
+		return func(ctx context.Context) (int, error) {
+			close(started)
+			select {
+			case <-other:
+				return n, nil
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			}
+		}
+	}
+	return wait(x, y, a), wait(y, x, b)
+}
+
+func add(a, b int) int {
+	return a + b
+}
+
+func boxedInts(ma warp.Result[[]int]) warp.Result[int] {
+	return result.Map(ma, func(ns []int) int { return len(ns) })
+}
+
+func boxed[A any](ma warp.Result[A]) warp.Result[any] {
+	return func(ctx context.Context) (any, error) {
+		a, err := ma(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return a, nil
+	}
+}
+
+// flaky creates a result which fails for the first n times it is run.
+func flaky(n int, a int) warp.Result[int] {
+	var attempts int32
+	return func(_ context.Context) (int, error) {
+		if int(atomic.AddInt32(&attempts, 1)) <= n {
+			return 0, errFailed
+		}
+		return a, nil
+	}
+}
+
 func double(n int) int {
 	return n * 2
 }
@@ -0,0 +1,200 @@
+package result
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/clock"
+	"github.com/onur1/warp/internal/flow"
+)
+
+// A RetryStatus describes the state of a retried result after an attempt has
+// failed.
+type RetryStatus struct {
+	// Attempt is the number of attempts made so far, starting with 1.
+	Attempt int
+	// Err is the error of the latest attempt.
+	Err error
+	// Elapsed is the time passed since the first attempt started.
+	Elapsed time.Duration
+	// Delay is the time waited before the latest attempt.
+	Delay time.Duration
+}
+
+// A RetryPolicy decides whether a failing result should be attempted again, and
+// how long to wait before doing so.
+type RetryPolicy func(RetryStatus) (time.Duration, bool)
+
+// And combines two policies into one which retries only if both of them do,
+// waiting for the longer of their delays.
+func (p RetryPolicy) And(q RetryPolicy) RetryPolicy {
+	return func(s RetryStatus) (time.Duration, bool) {
+		d1, ok := p(s)
+		if !ok {
+			return 0, false
+		}
+		d2, ok := q(s)
+		if !ok {
+			return 0, false
+		}
+		if d2 > d1 {
+			return d2, true
+		}
+		return d1, true
+	}
+}
+
+// ConstantBackoff creates a policy which always retries after the same delay.
+func ConstantBackoff(delay time.Duration) RetryPolicy {
+	return func(RetryStatus) (time.Duration, bool) {
+		return delay, true
+	}
+}
+
+// ExponentialBackoff creates a policy which always retries, doubling the delay
+// after each attempt starting with base.
+func ExponentialBackoff(base time.Duration) RetryPolicy {
+	return func(s RetryStatus) (time.Duration, bool) {
+		return scale(base, s.Attempt-1), true
+	}
+}
+
+// DecorrelatedJitter creates a policy which always retries after a random delay
+// between base and three times the previous delay, never exceeding maxDelay.
+func DecorrelatedJitter(base, maxDelay time.Duration) RetryPolicy {
+	return func(s RetryStatus) (time.Duration, bool) {
+		prev := s.Delay
+		if prev < base {
+			prev = base
+		}
+		upper := maxDelay
+		if prev <= maxDelay/3 {
+			upper = prev * 3
+		}
+		if upper <= base {
+			return upper, true
+		}
+		return base + time.Duration(rand.Int63n(int64(upper-base))), true
+	}
+}
+
+// CapDelay creates a policy which limits the delay of another policy.
+func CapDelay(p RetryPolicy, maxDelay time.Duration) RetryPolicy {
+	return func(s RetryStatus) (time.Duration, bool) {
+		d, ok := p(s)
+		if d > maxDelay {
+			d = maxDelay
+		}
+		return d, ok
+	}
+}
+
+// MaxAttempts creates a policy which retries without delay until a result has
+// been attempted n times in total.
+func MaxAttempts(n int) RetryPolicy {
+	return func(s RetryStatus) (time.Duration, bool) {
+		return 0, s.Attempt < n
+	}
+}
+
+// MaxElapsed creates a policy which retries without delay for as long as the
+// specified duration hasn't passed since the first attempt.
+func MaxElapsed(dur time.Duration) RetryPolicy {
+	return func(s RetryStatus) (time.Duration, bool) {
+		return 0, s.Elapsed < dur
+	}
+}
+
+// RetryIf creates a policy which retries without delay only if a predicate holds
+// on the error of the latest attempt.
+func RetryIf(predicate warp.Predicate[error]) RetryPolicy {
+	return func(s RetryStatus) (time.Duration, bool) {
+		return 0, predicate(s.Err)
+	}
+}
+
+// A RetryError is returned when a retried result doesn't succeed.
+type RetryError struct {
+	// Errors holds the errors of every attempt in order.
+	Errors []error
+	// Err is the error of the context if it was cancelled while waiting for the
+	// next attempt.
+	Err error
+}
+
+func (err *RetryError) Error() string {
+	last := err.Errors[len(err.Errors)-1]
+	if err.Err != nil {
+		return fmt.Sprintf("retry: %v after %d attempt(s): %v", err.Err, len(err.Errors), last)
+	}
+	return fmt.Sprintf("retry: failed after %d attempt(s): %v", len(err.Errors), last)
+}
+
+// Unwrap returns the errors of every attempt, followed by the error of the
+// context if any.
+func (err *RetryError) Unwrap() []error {
+	if err.Err != nil {
+		return append(append([]error(nil), err.Errors...), err.Err)
+	}
+	return err.Errors
+}
+
+// Retry creates a result which attempts a result again for as long as it fails and
+// the policy allows, waiting between attempts on the clock carried by the context.
+// It fails with a *RetryError if no attempt succeeds.
+func Retry[A any](ma warp.Result[A], policy RetryPolicy) warp.Result[A] {
+	return func(ctx context.Context) (a A, err error) {
+		var (
+			c      = clock.FromContext(ctx)
+			start  = c.Now()
+			status RetryStatus
+			rerr   = new(RetryError)
+			delay  time.Duration
+			ok     bool
+		)
+
+		for {
+			if a, err = ma(ctx); err == nil {
+				return
+			}
+
+			rerr.Errors = append(rerr.Errors, err)
+
+			status = RetryStatus{
+				Attempt: len(rerr.Errors),
+				Err:     err,
+				Elapsed: c.Now().Sub(start),
+				Delay:   status.Delay,
+			}
+
+			if delay, ok = policy(status); !ok {
+				break
+			}
+
+			if rerr.Err = flow.Sleep(ctx, clock.FromContext(ctx), delay); rerr.Err != nil {
+				break
+			}
+
+			status.Delay = delay
+		}
+
+		err = rerr
+
+		return
+	}
+}
+
+// scale multiplies a duration by 2^n, saturating instead of overflowing.
+func scale(d time.Duration, n int) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	if n >= 62 || d > time.Duration(math.MaxInt64>>n) {
+		return time.Duration(math.MaxInt64)
+	}
+	return d << n
+}
@@ -0,0 +1,63 @@
+package result
+
+import (
+	"context"
+	"time"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/clock"
+	"github.com/onur1/warp/internal/flow"
+)
+
+// ErrTimeout is the error of a result which doesn't finish in time. It reports
+// true from its Timeout method like the timeout errors of the net package.
+var ErrTimeout error = timeoutError{}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string {
+	return "timeout"
+}
+
+func (timeoutError) Timeout() bool {
+	return true
+}
+
+// Timeout creates a result which fails with ErrTimeout unless a result finishes
+// within the specified duration on the clock carried by the context. The result
+// runs with a derived context which is cancelled on timeout, and Timeout returns
+// without waiting for it to stop.
+func Timeout[A any](ma warp.Result[A], dur time.Duration) warp.Result[A] {
+	return func(ctx context.Context) (a A, err error) {
+		ctx, cancel := flow.WithCancel(ctx)
+		defer cancel()
+
+		type res struct {
+			a   A
+			err error
+		}
+
+		var (
+			rc    = make(chan res, 1)
+			timer = clock.FromContext(ctx).NewTimer(dur)
+		)
+
+		defer timer.Stop()
+
+		go func() {
+			a, err := Recover(ma)(ctx)
+			rc <- res{a, err}
+		}()
+
+		select {
+		case r := <-rc:
+			return r.a, r.err
+		case <-timer.C():
+			err = ErrTimeout
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+
+		return
+	}
+}
@@ -0,0 +1,87 @@
+package result
+
+import (
+	"context"
+
+	"github.com/onur1/warp"
+)
+
+// Sequence creates a result which runs a slice of results in order and returns
+// their values, failing with the first error.
+func Sequence[A any](mas []warp.Result[A]) warp.Result[[]A] {
+	return func(ctx context.Context) (_ []A, err error) {
+		as := make([]A, len(mas))
+		for i, ma := range mas {
+			if as[i], err = ma(ctx); err != nil {
+				return nil, err
+			}
+		}
+		return as, nil
+	}
+}
+
+// Traverse creates a result by applying a function which returns a result on each
+// value of a slice, running the results in order and failing with the first error.
+func Traverse[A, B any](as []A, f func(A) warp.Result[B]) warp.Result[[]B] {
+	return Sequence(results(as, f))
+}
+
+// TraverseMap creates a result by applying a function which returns a result on
+// each entry of a map, running the results one by one and failing with the first
+// error.
+func TraverseMap[K comparable, A, B any](m map[K]A, f func(K, A) warp.Result[B]) warp.Result[map[K]B] {
+	return func(ctx context.Context) (_ map[K]B, err error) {
+		bs := make(map[K]B, len(m))
+		for k, a := range m {
+			var b B
+			if b, err = f(k, a)(ctx); err != nil {
+				return nil, err
+			}
+			bs[k] = b
+		}
+		return bs, nil
+	}
+}
+
+// SequencePar is like Sequence but it runs at most limit results concurrently,
+// keeping the values in order; a limit which is not positive means no limit. It
+// is the same as All.
+func SequencePar[A any](mas []warp.Result[A], limit int) warp.Result[[]A] {
+	return All(mas, limit)
+}
+
+// TraversePar is like Traverse but it runs at most limit results concurrently,
+// keeping the values in order; a limit which is not positive means no limit.
+func TraversePar[A, B any](as []A, f func(A) warp.Result[B], limit int) warp.Result[[]B] {
+	return All(results(as, f), limit)
+}
+
+// TraverseMapPar is like TraverseMap but it runs at most limit results
+// concurrently; a limit which is not positive means no limit.
+func TraverseMapPar[K comparable, A, B any](m map[K]A, f func(K, A) warp.Result[B], limit int) warp.Result[map[K]B] {
+	var (
+		ks  = make([]K, 0, len(m))
+		mbs = make([]warp.Result[B], 0, len(m))
+	)
+
+	for k, a := range m {
+		ks = append(ks, k)
+		mbs = append(mbs, f(k, a))
+	}
+
+	return Map(All(mbs, limit), func(bs []B) map[K]B {
+		r := make(map[K]B, len(bs))
+		for i, b := range bs {
+			r[ks[i]] = b
+		}
+		return r
+	})
+}
+
+func results[A, B any](as []A, f func(A) warp.Result[B]) []warp.Result[B] {
+	mbs := make([]warp.Result[B], len(as))
+	for i, a := range as {
+		mbs[i] = f(a)
+	}
+	return mbs
+}
@@ -0,0 +1,221 @@
+// Package pull implements a pull-based, synchronous counterpart of the Stream
+// type. Values are produced on demand by the goroutine which asks for them, so
+// operators like Map, Filter and Take run fused into a single call per value
+// without spawning goroutines or passing values over channels.
+package pull
+
+import (
+	"context"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/internal/flow"
+)
+
+// A Stream produces values one at a time when asked for them. Each call returns
+// the next value and true, or false once the stream has ended, or an error if it
+// has failed. A stream which has ended or failed keeps reporting so. Streams are
+// stateful and they are not safe for concurrent use.
+type Stream[A any] func(ctx context.Context) (A, bool, error)
+
+// Next returns the next value of a stream.
+func (fa Stream[A]) Next(ctx context.Context) (A, bool, error) {
+	return fa(ctx)
+}
+
+// Of creates a stream which produces a single value.
+func Of[A any](a A) Stream[A] {
+	return From([]A{a})
+}
+
+// From creates a stream which produces the values of a slice in order.
+func From[A any](as []A) Stream[A] {
+	i := 0
+	return func(context.Context) (a A, ok bool, err error) {
+		if i >= len(as) {
+			return
+		}
+		a, ok = as[i], true
+		i += 1
+		return
+	}
+}
+
+// Fail creates a stream which fails with an error without producing any value.
+func Fail[A any](err error) Stream[A] {
+	return func(context.Context) (a A, ok bool, _ error) {
+		return a, false, err
+	}
+}
+
+// Map creates a stream by applying a function on each value of a source stream.
+func Map[A, B any](fa Stream[A], f func(A) B) Stream[B] {
+	return func(ctx context.Context) (b B, ok bool, err error) {
+		a, ok, err := fa(ctx)
+		if !ok || err != nil {
+			return b, false, err
+		}
+		return f(a), true, nil
+	}
+}
+
+// Filter creates a stream which produces the values of a source stream for which
+// a predicate holds.
+func Filter[A any](fa Stream[A], predicate warp.Predicate[A]) Stream[A] {
+	return func(ctx context.Context) (A, bool, error) {
+		for {
+			a, ok, err := fa(ctx)
+			if !ok || err != nil || predicate(a) {
+				return a, ok && err == nil, err
+			}
+		}
+	}
+}
+
+// Chain creates a stream which produces the values of the streams returned by a
+// function for each value of a source stream, one stream after another. It fails
+// with the first error of any of them.
+func Chain[A, B any](fa Stream[A], f func(A) Stream[B]) Stream[B] {
+	var (
+		fb  Stream[B]
+		err error
+	)
+
+	return func(ctx context.Context) (b B, ok bool, _ error) {
+		for err == nil {
+			if fb != nil {
+				if b, ok, err = fb(ctx); ok && err == nil {
+					return b, true, nil
+				}
+				fb = nil
+				continue
+			}
+
+			var a A
+
+			if a, ok, err = fa(ctx); !ok || err != nil {
+				return b, false, err
+			}
+
+			fb = f(a)
+		}
+
+		return b, false, err
+	}
+}
+
+// Take creates a stream which produces the first n values of a source stream,
+// without asking it for more.
+func Take[A any](fa Stream[A], n int) Stream[A] {
+	return func(ctx context.Context) (a A, ok bool, err error) {
+		if n <= 0 {
+			return
+		}
+		if a, ok, err = fa(ctx); ok && err == nil {
+			n -= 1
+		}
+		return
+	}
+}
+
+// Fold returns a value by applying a function on each value of a stream, in
+// order, passing in the value and the return value from the calculation on the
+// preceding element. It returns the value folded so far along with the error of
+// the stream if it fails.
+func Fold[A, B any](ctx context.Context, fa Stream[A], b B, f func(B, A) B) (B, error) {
+	for {
+		a, ok, err := fa(ctx)
+		if err != nil {
+			return b, err
+		}
+		if !ok {
+			return b, nil
+		}
+		b = f(b, a)
+	}
+}
+
+// FromEvent creates a stream which produces the values of an event, subscribing
+// to it in a new goroutine with a context derived from ctx. The returned function
+// cancels the event, and it must be called once the stream is no longer needed.
+// Asking for the next value fails with the error of the context passed to Next if
+// it is cancelled while waiting.
+func FromEvent[A any](ctx context.Context, fa warp.Event[A]) (Stream[A], context.CancelFunc) {
+	ctx, cancel := flow.WithCancel(ctx)
+
+	as := make(chan A)
+
+	go fa(ctx, as)
+
+	next := func(ctx context.Context) (a A, ok bool, err error) {
+		var done <-chan struct{}
+
+		if ctx != nil {
+			done = ctx.Done()
+		}
+
+		select {
+		case <-done:
+			return a, false, ctx.Err()
+		case a, ok = <-as:
+			return
+		}
+	}
+
+	return next, func() {
+		cancel()
+		for range as {
+		}
+	}
+}
+
+// ToEvent creates an event which emits the values of a stream until it ends or
+// fails, or until the context is cancelled.
+func ToEvent[A any](fa Stream[A]) warp.Event[A] {
+	return func(ctx context.Context, sub chan<- A) {
+		defer close(sub)
+
+		var done <-chan struct{}
+
+		if ctx != nil {
+			done = ctx.Done()
+		}
+
+		for {
+			a, ok, err := fa(ctx)
+			if !ok || err != nil {
+				return
+			}
+			select {
+			case <-done:
+				return
+			case sub <- a:
+			}
+		}
+	}
+}
+
+// ToStream creates a push-based stream which emits the values of a stream,
+// failing with its error, or with the error of the context if it is cancelled.
+func ToStream[A any](fa Stream[A]) warp.Stream[A] {
+	return func(ctx context.Context, sub chan<- A) error {
+		defer close(sub)
+
+		var done <-chan struct{}
+
+		if ctx != nil {
+			done = ctx.Done()
+		}
+
+		for {
+			a, ok, err := fa(ctx)
+			if !ok || err != nil {
+				return err
+			}
+			select {
+			case <-done:
+				return ctx.Err()
+			case sub <- a:
+			}
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package pull_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/event"
+	"github.com/onur1/warp/stream"
+	"github.com/onur1/warp/stream/pull"
+	"github.com/stretchr/testify/assert"
+)
+
+var errFailed = errors.New("failed")
+
+func TestStream(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		stream      func() pull.Stream[int]
+		expected    []int
+		expectedErr error
+	}{
+		{
+			desc:     "Of",
+			stream:   func() pull.Stream[int] { return pull.Of(42) },
+			expected: []int{42},
+		},
+		{
+			desc:     "From",
+			stream:   func() pull.Stream[int] { return pull.From([]int{1, 2, 3}) },
+			expected: []int{1, 2, 3},
+		},
+		{
+			desc:        "Fail",
+			stream:      func() pull.Stream[int] { return pull.Fail[int](errFailed) },
+			expectedErr: errFailed,
+		},
+		{
+			desc: "Map",
+			stream: func() pull.Stream[int] {
+				return pull.Map(pull.From([]int{1, 2, 3}), double)
+			},
+			expected: []int{2, 4, 6},
+		},
+		{
+			desc: "Filter",
+			stream: func() pull.Stream[int] {
+				return pull.Filter(pull.From([]int{-1, 2, -3, 4}), isPositive)
+			},
+			expected: []int{2, 4},
+		},
+		{
+			desc: "Chain",
+			stream: func() pull.Stream[int] {
+				return pull.Chain(pull.From([]int{1, 2, 3}), func(n int) pull.Stream[int] {
+					return pull.From([]int{n, n * 10})
+				})
+			},
+			expected: []int{1, 10, 2, 20, 3, 30},
+		},
+		{
+			desc: "Chain (empty)",
+			stream: func() pull.Stream[int] {
+				return pull.Chain(pull.From([]int{1, 2, 3}), func(n int) pull.Stream[int] {
+					return pull.From[int](nil)
+				})
+			},
+		},
+		{
+			desc: "Chain (error)",
+			stream: func() pull.Stream[int] {
+				return pull.Chain(pull.From([]int{1, 2, 3}), failOn(2))
+			},
+			expected:    []int{1},
+			expectedErr: errFailed,
+		},
+		{
+			desc: "Take",
+			stream: func() pull.Stream[int] {
+				return pull.Take(pull.Map(naturals(), double), 3)
+			},
+			expected: []int{0, 2, 4},
+		},
+		{
+			desc: "Take (short)",
+			stream: func() pull.Stream[int] {
+				return pull.Take(pull.From([]int{1}), 3)
+			},
+			expected: []int{1},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ns, err := pull.Fold(context.TODO(), tC.stream(), []int(nil), func(ns []int, n int) []int {
+				return append(ns, n)
+			})
+			assert.Equal(t, tC.expected, ns)
+			assert.Equal(t, tC.expectedErr, err)
+		})
+	}
+}
+
+func TestEnded(t *testing.T) {
+	fa := pull.Take(pull.From([]int{1, 2}), 1)
+
+	n, ok, err := fa.Next(context.TODO())
+	assert.Equal(t, 1, n)
+	assert.True(t, ok)
+	assert.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, ok, err = fa.Next(context.TODO())
+		assert.False(t, ok)
+		assert.NoError(t, err)
+	}
+}
+
+func TestEvent(t *testing.T) {
+	fa, cancel := pull.FromEvent(context.TODO(), event.From([]int{1, 2, 3}))
+	defer cancel()
+
+	ns, err := pull.Fold(context.TODO(), pull.Map(fa, double), 0, sum)
+	assert.Equal(t, 12, ns)
+	assert.NoError(t, err)
+
+	var (
+		ctx, stop = context.WithCancel(context.Background())
+		as        = make(chan int)
+	)
+
+	go pull.ToEvent(pull.Take(naturals(), 3))(ctx, as)
+
+	var collected []int
+	for a := range as {
+		collected = append(collected, a)
+	}
+
+	assert.Equal(t, []int{0, 1, 2}, collected)
+
+	stop()
+}
+
+func TestFromEventCancel(t *testing.T) {
+	never := func(ctx context.Context, sub chan<- int) {
+		defer close(sub)
+		<-ctx.Done()
+	}
+
+	fa, cancel := pull.FromEvent(context.TODO(), warp.Event[int](never))
+
+	ctx, stop := context.WithCancel(context.Background())
+	stop()
+
+	_, ok, err := fa.Next(ctx)
+	assert.False(t, ok)
+	assert.Equal(t, context.Canceled, err)
+
+	cancel()
+
+	_, ok, err = fa.Next(context.TODO())
+	assert.False(t, ok)
+	assert.NoError(t, err)
+}
+
+func TestToStream(t *testing.T) {
+	n, err := stream.Reduce(context.TODO(), pull.ToStream(pull.From([]int{1, 2, 3})), 0, sum)
+	assert.Equal(t, 6, n)
+	assert.NoError(t, err)
+
+	n, err = stream.Reduce(context.TODO(), pull.ToStream(pull.Chain(pull.From([]int{1, 2, 3}), failOn(3))), 0, sum)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, errFailed, err)
+}
+
+func BenchmarkPipeline(b *testing.B) {
+	ns := make([]int, 1000)
+	for i := range ns {
+		ns[i] = i - 500
+	}
+
+	b.Run("pull", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = pull.Fold(context.TODO(), pull.Map(pull.Filter(pull.Map(pull.From(ns), double), isPositive), double), 0, sum)
+		}
+	})
+
+	b.Run("stream", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = stream.Reduce(context.TODO(), stream.Map(stream.Filter(stream.Map(stream.From(ns), double), isPositive), double), 0, sum)
+		}
+	})
+}
+
+func naturals() pull.Stream[int] {
+	n := 0
+	return func(context.Context) (int, bool, error) {
+		n += 1
+		return n - 1, true, nil
+	}
+}
+
+func failOn(n int) func(int) pull.Stream[int] {
+	return func(a int) pull.Stream[int] {
+		if a == n {
+			return pull.Fail[int](errFailed)
+		}
+		return pull.Of(a)
+	}
+}
+
+func double(n int) int {
+	return n * 2
+}
+
+func isPositive(n int) bool {
+	return n > 0
+}
+
+func sum(a, b int) int {
+	return a + b
+}
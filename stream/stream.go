@@ -0,0 +1,301 @@
+// Package stream implements the Stream type.
+package stream
+
+import (
+	"context"
+	"errors"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/internal/flow"
+	"github.com/onur1/warp/result"
+)
+
+// Of creates a stream which emits a single value.
+func Of[A any](a A) warp.Stream[A] {
+	return From([]A{a})
+}
+
+// From creates a stream which emits multiple values sequentially from the supplied
+// slice.
+func From[A any](as []A) warp.Stream[A] {
+	return func(ctx context.Context, sub chan<- A) error {
+		defer close(sub)
+
+		var done <-chan struct{}
+
+		if ctx != nil {
+			done = ctx.Done()
+		}
+
+		for _, a := range as {
+			select {
+			case <-done:
+				return ctx.Err()
+			case sub <- a:
+			}
+		}
+
+		return nil
+	}
+}
+
+// Fail creates a stream which fails with an error without emitting any value.
+func Fail[A any](err error) warp.Stream[A] {
+	return func(_ context.Context, sub chan<- A) error {
+		close(sub)
+		return err
+	}
+}
+
+// FromEvent creates a stream which emits the values of an event, failing only if
+// the context is cancelled.
+func FromEvent[A any](fa warp.Event[A]) warp.Stream[A] {
+	return func(ctx context.Context, sub chan<- A) error {
+		fa(ctx, sub)
+		if ctx != nil {
+			return ctx.Err()
+		}
+		return nil
+	}
+}
+
+// FromResult creates a stream which emits the value of a result, or fails with
+// its error.
+func FromResult[A any](ra warp.Result[A]) warp.Stream[A] {
+	return func(ctx context.Context, sub chan<- A) error {
+		defer close(sub)
+
+		a, err := ra(ctx)
+		if err != nil {
+			return err
+		}
+
+		var done <-chan struct{}
+
+		if ctx != nil {
+			done = ctx.Done()
+		}
+
+		select {
+		case <-done:
+			return ctx.Err()
+		case sub <- a:
+		}
+
+		return nil
+	}
+}
+
+// FromFuture creates a stream which emits the values of the succeeding results of a
+// future, failing with the error of the first failing result.
+func FromFuture[A any](fa warp.Future[A]) warp.Stream[A] {
+	return func(ctx context.Context, sub chan<- A) error {
+		defer close(sub)
+
+		ctx, cancel := flow.WithCancel(ctx)
+		defer cancel()
+
+		var (
+			ras  = make(chan warp.Result[A])
+			done = ctx.Done()
+		)
+
+		go fa(ctx, ras)
+
+		for ra := range ras {
+			a, err := ra(ctx)
+			if err != nil {
+				return err
+			}
+			select {
+			case <-done:
+				return ctx.Err()
+			case sub <- a:
+			}
+		}
+
+		return ctx.Err()
+	}
+}
+
+// ToEvent creates an event which emits the values of a stream, discarding the
+// error it fails with.
+func ToEvent[A any](fa warp.Stream[A]) warp.Event[A] {
+	return func(ctx context.Context, sub chan<- A) {
+		_ = fa(ctx, sub)
+	}
+}
+
+// ToFuture creates a future which succeeds with the values of a stream, and fails
+// with the error of the stream as its last result.
+func ToFuture[A any](fa warp.Stream[A]) warp.Future[A] {
+	return func(ctx context.Context, sub chan<- warp.Result[A]) {
+		defer close(sub)
+
+		var done <-chan struct{}
+
+		if ctx != nil {
+			done = ctx.Done()
+		}
+
+		as, errc := run(ctx, fa)
+
+		for a := range as {
+			select {
+			case <-done:
+				return
+			case sub <- result.Ok(a):
+			}
+		}
+
+		if err := <-errc; err != nil {
+			select {
+			case <-done:
+			case sub <- result.Error[A](err):
+			}
+		}
+	}
+}
+
+// Using creates a stream which acquires a resource, emits the values of the stream
+// created from it, and then releases it. The resource is released exactly once
+// after the inner stream has ended, before the stream reports its error, and an
+// error returned by release is joined into that error. Nothing is emitted if
+// acquire fails.
+func Using[R, A any](acquire warp.Result[R], f func(R) warp.Stream[A], release func(R) error) warp.Stream[A] {
+	return func(ctx context.Context, sub chan<- A) (err error) {
+		var r R
+
+		if r, err = acquire(ctx); err != nil {
+			close(sub)
+			return
+		}
+
+		defer func() {
+			if rerr := release(r); rerr != nil {
+				err = errors.Join(err, rerr)
+			}
+		}()
+
+		return f(r)(ctx, sub)
+	}
+}
+
+// Map creates a stream by applying a function on each value received from a source
+// stream.
+func Map[A, B any](fa warp.Stream[A], f func(A) B) warp.Stream[B] {
+	return func(ctx context.Context, sub chan<- B) error {
+		defer close(sub)
+
+		ctx, cancel := flow.WithCancel(ctx)
+		defer cancel()
+
+		var (
+			as, errc = run(ctx, fa)
+			done     = ctx.Done()
+		)
+
+		for a := range as {
+			select {
+			case <-done:
+				return ctx.Err()
+			case sub <- f(a):
+			}
+		}
+
+		return <-errc
+	}
+}
+
+// Filter creates a stream which emits values from a source stream when a predicate
+// holds.
+func Filter[A any](fa warp.Stream[A], predicate warp.Predicate[A]) warp.Stream[A] {
+	return func(ctx context.Context, sub chan<- A) error {
+		defer close(sub)
+
+		ctx, cancel := flow.WithCancel(ctx)
+		defer cancel()
+
+		var (
+			as, errc = run(ctx, fa)
+			done     = ctx.Done()
+		)
+
+		for a := range as {
+			if !predicate(a) {
+				continue
+			}
+			select {
+			case <-done:
+				return ctx.Err()
+			case sub <- a:
+			}
+		}
+
+		return <-errc
+	}
+}
+
+// Chain creates a stream which composes two streams in sequence, using each value
+// of the first stream to determine the next one. It fails with the first error of
+// either stream, cancelling the other.
+func Chain[A, B any](fa warp.Stream[A], f func(A) warp.Stream[B]) warp.Stream[B] {
+	return func(ctx context.Context, sub chan<- B) error {
+		defer close(sub)
+
+		ctx, cancel := flow.WithCancel(ctx)
+		defer cancel()
+
+		var (
+			as, errc = run(ctx, fa)
+			done     = ctx.Done()
+		)
+
+		for a := range as {
+			bs, berrc := run(ctx, f(a))
+
+			for b := range bs {
+				select {
+				case <-done:
+					return ctx.Err()
+				case sub <- b:
+				}
+			}
+
+			if err := <-berrc; err != nil {
+				return err
+			}
+		}
+
+		return <-errc
+	}
+}
+
+// Reduce returns a value by applying a function on each value received from a
+// stream, in order, passing in the value and the return value from the calculation
+// on the preceding element. It returns the value reduced so far along with the
+// error of the stream if it fails.
+func Reduce[A, B any](ctx context.Context, fa warp.Stream[A], b B, f func(B, A) B) (B, error) {
+	as, errc := run(ctx, fa)
+
+	for a := range as {
+		b = f(b, a)
+	}
+
+	return b, <-errc
+}
+
+// run subscribes to a stream in a new goroutine, returning the channel it emits to
+// and a channel which receives its error once it has ended.
+func run[A any](ctx context.Context, fa warp.Stream[A]) (chan A, chan error) {
+	var (
+		as   = make(chan A)
+		errc = make(chan error, 1)
+	)
+
+	go func() {
+		errc <- fa(ctx, as)
+	}()
+
+	return as, errc
+}
@@ -0,0 +1,227 @@
+package stream_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/event"
+	"github.com/onur1/warp/future"
+	"github.com/onur1/warp/result"
+	"github.com/onur1/warp/stream"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	errFailed = errors.New("failed")
+	errFirst  = errors.New("first")
+)
+
+func TestStream(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		stream      warp.Stream[int]
+		expected    []int
+		expectedErr error
+	}{
+		{
+			desc:     "Of",
+			stream:   stream.Of(42),
+			expected: []int{42},
+		},
+		{
+			desc:     "From",
+			stream:   stream.From([]int{1, 2, 3}),
+			expected: []int{1, 2, 3},
+		},
+		{
+			desc:        "Fail",
+			stream:      stream.Fail[int](errFailed),
+			expectedErr: errFailed,
+		},
+		{
+			desc:     "FromEvent",
+			stream:   stream.FromEvent(event.From([]int{1, 2})),
+			expected: []int{1, 2},
+		},
+		{
+			desc:     "FromResult",
+			stream:   stream.FromResult(result.Ok(42)),
+			expected: []int{42},
+		},
+		{
+			desc:        "FromResult (error)",
+			stream:      stream.FromResult(result.Error[int](errFailed)),
+			expectedErr: errFailed,
+		},
+		{
+			desc: "FromFuture",
+			stream: stream.FromFuture(future.FromResults([]warp.Result[int]{
+				result.Ok(1),
+				result.Error[int](errFirst),
+				result.Ok(3),
+			})),
+			expected:    []int{1},
+			expectedErr: errFirst,
+		},
+		{
+			desc:     "Map",
+			stream:   stream.Map(stream.From([]int{1, 2, 3}), double),
+			expected: []int{2, 4, 6},
+		},
+		{
+			desc:        "Map (error)",
+			stream:      stream.Map(stream.Chain(stream.From([]int{1, 2, 3}), failOn(2)), double),
+			expected:    []int{2},
+			expectedErr: errFailed,
+		},
+		{
+			desc:     "Filter",
+			stream:   stream.Filter(stream.From([]int{-1, 2, -3, 4}), isPositive),
+			expected: []int{2, 4},
+		},
+		{
+			desc: "Chain",
+			stream: stream.Chain(stream.From([]int{1, 2}), func(n int) warp.Stream[int] {
+				return stream.From([]int{n, n * 10})
+			}),
+			expected: []int{1, 10, 2, 20},
+		},
+		{
+			desc:        "Chain (error)",
+			stream:      stream.Chain(stream.From([]int{1, 2, 3}), failOn(2)),
+			expected:    []int{1},
+			expectedErr: errFailed,
+		},
+		{
+			desc: "Chain (outer error)",
+			stream: stream.Chain(stream.Fail[int](errFirst), func(n int) warp.Stream[int] {
+				return stream.Of(n)
+			}),
+			expectedErr: errFirst,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			collected, err := stream.Reduce(context.TODO(), tC.stream, nil, func(as []int, a int) []int {
+				return append(as, a)
+			})
+			assert.Equal(t, tC.expected, collected)
+			assert.Equal(t, tC.expectedErr, err)
+		})
+	}
+}
+
+func TestToFuture(t *testing.T) {
+	r := make(chan warp.Result[int])
+
+	go stream.ToFuture(stream.Chain(stream.From([]int{1, 2}), failOn(2)))(context.TODO(), r)
+
+	var (
+		values []int
+		errs   []error
+	)
+
+	for ra := range r {
+		result.Fork(context.TODO(), ra, func(err error) {
+			errs = append(errs, err)
+		}, func(a int) {
+			values = append(values, a)
+		})
+	}
+
+	assert.Equal(t, []int{1}, values)
+	assert.Equal(t, []error{errFailed}, errs)
+}
+
+func TestCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	r := make(chan int)
+
+	errc := make(chan error, 1)
+
+	go func() {
+		errc <- stream.Map(stream.FromEvent(event.Empty()), func(struct{}) int { return 1 })(ctx, r)
+	}()
+
+	<-r
+
+	cancel()
+
+	for range r {
+	}
+
+	assert.Equal(t, context.Canceled, <-errc)
+}
+
+func TestUsing(t *testing.T) {
+	errRelease := errors.New("release")
+
+	testCases := []struct {
+		desc        string
+		acquire     warp.Result[int]
+		use         func(int) warp.Stream[int]
+		expected    []int
+		expectedErr []error
+		released    int
+	}{
+		{
+			desc:        "succeed",
+			acquire:     result.Ok(2),
+			use:         func(n int) warp.Stream[int] { return stream.From([]int{n, n}) },
+			expected:    []int{2, 2},
+			expectedErr: []error{errRelease},
+			released:    1,
+		},
+		{
+			desc:        "use fails",
+			acquire:     result.Ok(2),
+			use:         failOn(2),
+			expectedErr: []error{errFailed, errRelease},
+			released:    1,
+		},
+		{
+			desc:        "acquire fails",
+			acquire:     result.Error[int](errFirst),
+			use:         stream.Of[int],
+			expectedErr: []error{errFirst},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			released := 0
+
+			collected, err := stream.Reduce(context.TODO(), stream.Using(tC.acquire, tC.use, func(int) error {
+				released++
+				return errRelease
+			}), nil, func(as []int, a int) []int {
+				return append(as, a)
+			})
+
+			assert.Equal(t, tC.expected, collected)
+			for _, expectedErr := range tC.expectedErr {
+				assert.ErrorIs(t, err, expectedErr)
+			}
+			assert.Equal(t, tC.released, released)
+		})
+	}
+}
+
+func failOn(n int) func(int) warp.Stream[int] {
+	return func(a int) warp.Stream[int] {
+		if a == n {
+			return stream.Fail[int](errFailed)
+		}
+		return stream.Of(a)
+	}
+}
+
+func double(n int) int {
+	return n * 2
+}
+
+func isPositive(n int) bool {
+	return n > 0
+}
@@ -21,8 +21,30 @@ type Event[A any] func(context.Context, chan<- A)
 // a value which is encapsulated in a Result.
 type Future[A any] Event[Result[A]]
 
+// A Stream represents a collection of discrete occurrences of events with associated
+// values, which may end with an error. Unlike a Future, a Stream fails at most once,
+// and a failure ends it.
+type Stream[A any] func(context.Context, chan<- A) error
+
+// A Validation represents a result of a validation which is either a value of type A,
+// or an error which may hold many errors.
+type Validation[A any] func() (A, error)
+
 // A Predicate represents a predicate (boolean-valued function) of one argument.
 type Predicate[A any] func(A) bool
 
 // A Nilable represents an optional value which is either some value or nil.
 type Nilable[A any] *A
+
+// A Tuple represents a pair of values.
+type Tuple[A, B any] struct {
+	First  A
+	Second B
+}
+
+// A Tuple3 represents a triple of values.
+type Tuple3[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
@@ -0,0 +1,275 @@
+// Package validation implements the Validation type.
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onur1/warp"
+)
+
+// A FieldError represents a problem with the value at some path of a validated
+// structure, such as "servers[2].port".
+type FieldError struct {
+	Path    string
+	Message string
+	Err     error
+}
+
+func (err *FieldError) Error() string {
+	if err.Path == "" {
+		return err.Message
+	}
+	return err.Path + ": " + err.Message
+}
+
+// Unwrap returns the error which has caused the problem, if any.
+func (err *FieldError) Unwrap() error {
+	return err.Err
+}
+
+// Errors represents every problem found by a failing validation, in order.
+type Errors []*FieldError
+
+func (errs Errors) Error() string {
+	ss := make([]string, len(errs))
+	for i, err := range errs {
+		ss[i] = err.Error()
+	}
+	return strings.Join(ss, "; ")
+}
+
+// Unwrap returns the field errors.
+func (errs Errors) Unwrap() []error {
+	es := make([]error, len(errs))
+	for i, err := range errs {
+		es[i] = err
+	}
+	return es
+}
+
+// Ok creates a validation which always succeeds with a value.
+func Ok[A any](a A) warp.Validation[A] {
+	return func() (A, error) {
+		return a, nil
+	}
+}
+
+// Invalid creates a validation which always fails with a message about the value
+// at a path.
+func Invalid[A any](path, message string) warp.Validation[A] {
+	return fail[A](Errors{{Path: path, Message: message}})
+}
+
+// Invalidf is like Invalid but it formats the message according to a format
+// specifier.
+func Invalidf[A any](path, format string, args ...any) warp.Validation[A] {
+	return Invalid[A](path, fmt.Sprintf(format, args...))
+}
+
+// FromError creates a validation which always fails with an error about the value
+// at a path. An Errors error keeps its field errors, prefixed with the path. If the
+// error is nil, the validation succeeds with the zero value.
+func FromError[A any](path string, err error) warp.Validation[A] {
+	if err == nil {
+		var a A
+		return Ok(a)
+	}
+	return fail[A](prefix(path, errorsOf(err)))
+}
+
+// FromPredicate creates a validation by testing a value against a predicate,
+// failing with a message about the value at a path if it doesn't hold.
+func FromPredicate[A any](a A, predicate warp.Predicate[A], path, message string) warp.Validation[A] {
+	if predicate(a) {
+		return Ok(a)
+	}
+	return Invalid[A](path, message)
+}
+
+// FromResult creates a validation from a result, failing with the error of a
+// failing result. The result runs with the context each time the validation runs.
+func FromResult[A any](ctx context.Context, ma warp.Result[A]) warp.Validation[A] {
+	return func() (A, error) {
+		a, err := ma(ctx)
+		if err != nil {
+			return FromError[A]("", err)()
+		}
+		return a, nil
+	}
+}
+
+// ToResult creates a result from a validation, which fails with Errors.
+func ToResult[A any](va warp.Validation[A]) warp.Result[A] {
+	return func(_ context.Context) (A, error) {
+		return va()
+	}
+}
+
+// Field creates a validation which prefixes the paths of the errors of another
+// validation with a path, so that validations of nested structures can be built
+// from validations of their fields.
+func Field[A any](path string, va warp.Validation[A]) warp.Validation[A] {
+	return func() (a A, err error) {
+		if a, err = va(); err != nil {
+			return a, prefix(path, errorsOf(err))
+		}
+		return
+	}
+}
+
+// Map creates a validation by applying a function on a succeeding validation.
+func Map[A, B any](fa warp.Validation[A], f func(A) B) warp.Validation[B] {
+	return func() (b B, err error) {
+		var a A
+		if a, err = fa(); err != nil {
+			return b, errorsOf(err)
+		}
+		return f(a), nil
+	}
+}
+
+// Ap creates a validation by applying a function contained in the first
+// validation on the value contained in the second validation, accumulating the
+// errors of both if they fail.
+func Ap[A, B any](fab warp.Validation[func(A) B], fa warp.Validation[A]) warp.Validation[B] {
+	return Map2(fab, fa, func(ab func(A) B, a A) B {
+		return ab(a)
+	})
+}
+
+// Chain creates a validation which combines two validations in sequence, using the
+// return value of the first validation to determine the next one. Unlike Ap, it
+// doesn't accumulate errors, as the second validation can't run if the first one
+// fails.
+func Chain[A, B any](ma warp.Validation[A], f func(A) warp.Validation[B]) warp.Validation[B] {
+	return func() (b B, err error) {
+		var a A
+		if a, err = ma(); err != nil {
+			return b, errorsOf(err)
+		}
+		return f(a)()
+	}
+}
+
+// Map2 creates a validation by combining the values of two validations with a
+// function, accumulating the errors of both if they fail.
+func Map2[A, B, C any](fa warp.Validation[A], fb warp.Validation[B], fn func(A, B) C) warp.Validation[C] {
+	return func() (c C, err error) {
+		var (
+			errs Errors
+			a    = collect(fa, &errs)
+			b    = collect(fb, &errs)
+		)
+		if len(errs) > 0 {
+			return c, errs
+		}
+		return fn(a, b), nil
+	}
+}
+
+// Map3 is like Map2 but it combines the values of three validations.
+func Map3[A, B, C, D any](fa warp.Validation[A], fb warp.Validation[B], fc warp.Validation[C], fn func(A, B, C) D) warp.Validation[D] {
+	return func() (d D, err error) {
+		var (
+			errs Errors
+			a    = collect(fa, &errs)
+			b    = collect(fb, &errs)
+			c    = collect(fc, &errs)
+		)
+		if len(errs) > 0 {
+			return d, errs
+		}
+		return fn(a, b, c), nil
+	}
+}
+
+// Map4 is like Map2 but it combines the values of four validations.
+func Map4[A, B, C, D, E any](fa warp.Validation[A], fb warp.Validation[B], fc warp.Validation[C], fd warp.Validation[D], fn func(A, B, C, D) E) warp.Validation[E] {
+	return func() (e E, err error) {
+		var (
+			errs Errors
+			a    = collect(fa, &errs)
+			b    = collect(fb, &errs)
+			c    = collect(fc, &errs)
+			d    = collect(fd, &errs)
+		)
+		if len(errs) > 0 {
+			return e, errs
+		}
+		return fn(a, b, c, d), nil
+	}
+}
+
+// Traverse creates a validation by applying a function which returns a validation
+// on each value of a slice, accumulating the errors of all of them. The paths of
+// the errors are prefixed with the index of the value, as in "[2].port".
+func Traverse[A, B any](as []A, f func(A) warp.Validation[B]) warp.Validation[[]B] {
+	return func() ([]B, error) {
+		var (
+			errs Errors
+			bs   = make([]B, len(as))
+		)
+		for i, a := range as {
+			bs[i] = collect(Field(fmt.Sprintf("[%d]", i), f(a)), &errs)
+		}
+		if len(errs) > 0 {
+			return nil, errs
+		}
+		return bs, nil
+	}
+}
+
+// collect runs a validation and appends its errors to errs.
+func collect[A any](va warp.Validation[A], errs *Errors) A {
+	a, err := va()
+	if err != nil {
+		*errs = append(*errs, errorsOf(err)...)
+	}
+	return a
+}
+
+func fail[A any](errs Errors) warp.Validation[A] {
+	return func() (a A, _ error) {
+		return a, errs
+	}
+}
+
+// errorsOf converts an error to Errors, wrapping it in a field error with no path
+// unless it already is one.
+func errorsOf(err error) Errors {
+	var (
+		errs Errors
+		ferr *FieldError
+	)
+	if errors.As(err, &errs) {
+		return errs
+	}
+	if errors.As(err, &ferr) {
+		return Errors{ferr}
+	}
+	return Errors{{Message: err.Error(), Err: err}}
+}
+
+// prefix returns a copy of errs where each path is prefixed with a path.
+func prefix(path string, errs Errors) Errors {
+	if path == "" {
+		return errs
+	}
+	r := make(Errors, len(errs))
+	for i, err := range errs {
+		e := *err
+		switch {
+		case e.Path == "":
+			e.Path = path
+		case strings.HasPrefix(e.Path, "["):
+			e.Path = path + e.Path
+		default:
+			e.Path = path + "." + e.Path
+		}
+		r[i] = &e
+	}
+	return r
+}
@@ -0,0 +1,231 @@
+package validation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/result"
+	"github.com/onur1/warp/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+var errFailed = errors.New("failed")
+
+type server struct {
+	host string
+	port int
+}
+
+func nonEmpty(path, s string) warp.Validation[string] {
+	return validation.FromPredicate(s, func(s string) bool { return s != "" }, path, "must not be empty")
+}
+
+func validPort(path string, n int) warp.Validation[int] {
+	return validation.FromPredicate(n, func(n int) bool { return n > 0 && n < 65536 }, path, "must be a valid port")
+}
+
+func validServer(s server) warp.Validation[server] {
+	return validation.Map2(nonEmpty("host", s.host), validPort("port", s.port), func(host string, port int) server {
+		return server{host, port}
+	})
+}
+
+func TestValidation(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		validation warp.Validation[int]
+		expected   int
+		errors     validation.Errors
+	}{
+		{
+			desc:       "Ok",
+			validation: validation.Ok(42),
+			expected:   42,
+		},
+		{
+			desc:       "Invalid",
+			validation: validation.Invalid[int]("n", "is wrong"),
+			errors:     validation.Errors{{Path: "n", Message: "is wrong"}},
+		},
+		{
+			desc:       "Invalidf",
+			validation: validation.Invalidf[int]("n", "must be less than %d", 10),
+			errors:     validation.Errors{{Path: "n", Message: "must be less than 10"}},
+		},
+		{
+			desc:       "Map",
+			validation: validation.Map(validation.Ok(42), double),
+			expected:   84,
+		},
+		{
+			desc:       "Map (invalid)",
+			validation: validation.Map(validation.Invalid[int]("n", "is wrong"), double),
+			errors:     validation.Errors{{Path: "n", Message: "is wrong"}},
+		},
+		{
+			desc:       "Ap",
+			validation: validation.Ap(validation.Ok(double), validation.Ok(42)),
+			expected:   84,
+		},
+		{
+			desc: "Ap (accumulate)",
+			validation: validation.Ap(
+				validation.Invalid[func(int) int]("f", "is wrong"),
+				validation.Invalid[int]("n", "is wrong"),
+			),
+			errors: validation.Errors{
+				{Path: "f", Message: "is wrong"},
+				{Path: "n", Message: "is wrong"},
+			},
+		},
+		{
+			desc: "Chain",
+			validation: validation.Chain(validation.Ok(42), func(n int) warp.Validation[int] {
+				return validation.Ok(n * 2)
+			}),
+			expected: 84,
+		},
+		{
+			desc: "Chain (short-circuit)",
+			validation: validation.Chain(validation.Invalid[int]("a", "is wrong"), func(n int) warp.Validation[int] {
+				return validation.Invalid[int]("b", "is wrong")
+			}),
+			errors: validation.Errors{{Path: "a", Message: "is wrong"}},
+		},
+		{
+			desc: "Map3",
+			validation: validation.Map3(validation.Ok(1), validation.Ok(2), validation.Ok(3), func(a, b, c int) int {
+				return a + b + c
+			}),
+			expected: 6,
+		},
+		{
+			desc: "Map4 (accumulate)",
+			validation: validation.Map4(
+				validation.Invalid[int]("a", "is wrong"),
+				validation.Ok(2),
+				validation.Invalid[int]("c", "is wrong"),
+				validation.Invalid[int]("d", "is wrong"),
+				func(a, b, c, d int) int {
+					return a + b + c + d
+				},
+			),
+			errors: validation.Errors{
+				{Path: "a", Message: "is wrong"},
+				{Path: "c", Message: "is wrong"},
+				{Path: "d", Message: "is wrong"},
+			},
+		},
+		{
+			desc:       "FromResult",
+			validation: validation.FromResult(context.TODO(), result.Ok(42)),
+			expected:   42,
+		},
+		{
+			desc:       "FromResult (fail)",
+			validation: validation.FromResult(context.TODO(), result.Error[int](errFailed)),
+			errors:     validation.Errors{{Message: "failed", Err: errFailed}},
+		},
+		{
+			desc:       "FromError",
+			validation: validation.FromError[int]("n", errFailed),
+			errors:     validation.Errors{{Path: "n", Message: "failed", Err: errFailed}},
+		},
+		{
+			desc:       "FromError (nil)",
+			validation: validation.FromError[int]("n", nil),
+			expected:   0,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			n, err := tC.validation()
+			if tC.errors != nil {
+				assert.Equal(t, tC.errors, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tC.expected, n)
+			}
+		})
+	}
+}
+
+func TestField(t *testing.T) {
+	type config struct {
+		name    string
+		servers []server
+	}
+
+	validConfig := func(c config) warp.Validation[config] {
+		return validation.Map2(
+			nonEmpty("name", c.name),
+			validation.Field("servers", validation.Traverse(c.servers, validServer)),
+			func(name string, servers []server) config {
+				return config{name, servers}
+			},
+		)
+	}
+
+	c, err := validConfig(config{
+		name:    "foo",
+		servers: []server{{"localhost", 80}, {"example.com", 443}},
+	})()
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", c.name)
+	assert.Len(t, c.servers, 2)
+
+	_, err = validation.Field("config", validConfig(config{
+		servers: []server{{"localhost", 80}, {"", 0}, {"example.com", 99999}},
+	}))()
+
+	var errs validation.Errors
+	assert.True(t, errors.As(err, &errs))
+	assert.Equal(t, validation.Errors{
+		{Path: "config.name", Message: "must not be empty"},
+		{Path: "config.servers[1].host", Message: "must not be empty"},
+		{Path: "config.servers[1].port", Message: "must be a valid port"},
+		{Path: "config.servers[2].port", Message: "must be a valid port"},
+	}, errs)
+	assert.EqualError(t, err, "config.name: must not be empty; "+
+		"config.servers[1].host: must not be empty; "+
+		"config.servers[1].port: must be a valid port; "+
+		"config.servers[2].port: must be a valid port")
+}
+
+func TestFromResult(t *testing.T) {
+	type key struct{}
+
+	runs := 0
+
+	va := validation.FromResult(context.WithValue(context.TODO(), key{}, 42), func(ctx context.Context) (int, error) {
+		runs += 1
+		return ctx.Value(key{}).(int), nil
+	})
+	assert.Equal(t, 0, runs)
+
+	for i := 1; i <= 2; i++ {
+		n, err := va()
+		assert.NoError(t, err)
+		assert.Equal(t, 42, n)
+		assert.Equal(t, i, runs)
+	}
+}
+
+func TestToResult(t *testing.T) {
+	n, err := validation.ToResult(validation.Ok(42))(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, 42, n)
+
+	_, err = validation.ToResult(validation.FromError[int]("n", errFailed))(context.TODO())
+	assert.ErrorIs(t, err, errFailed)
+
+	var ferr *validation.FieldError
+	assert.True(t, errors.As(err, &ferr))
+	assert.Equal(t, "n", ferr.Path)
+}
+
+func double(n int) int {
+	return n * 2
+}
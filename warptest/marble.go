@@ -0,0 +1,198 @@
+package warptest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type kind int
+
+const (
+	next kind = iota
+	failure
+	completion
+	cancellation
+)
+
+// A notification represents something which happened at a frame of a marble.
+type notification[A any] struct {
+	frame int
+	kind  kind
+	key   rune
+	value A
+	err   error
+}
+
+func (n notification[A]) symbol() rune {
+	switch n.kind {
+	case failure:
+		return '#'
+	case completion:
+		return '|'
+	case cancellation:
+		return '!'
+	}
+	return n.key
+}
+
+// parse parses a marble into the notifications it describes and the number of
+// frames it spans, looking up the values of the notifications by their keys.
+func parse[A any](marble string, values map[rune]A, err error) ([]notification[A], int, error) {
+	var (
+		ns         []notification[A]
+		frame      = 0
+		group      = -1
+		terminated = false
+	)
+
+	for _, r := range marble {
+		if r == ' ' {
+			continue
+		}
+
+		at := frame
+		if group >= 0 {
+			at = group
+		}
+
+		switch r {
+		case '-':
+			if group >= 0 {
+				return nil, 0, fmt.Errorf("warptest: frame inside a group in %q", marble)
+			}
+		case '(':
+			if group >= 0 {
+				return nil, 0, fmt.Errorf("warptest: nested group in %q", marble)
+			}
+			group = frame
+		case ')':
+			if group < 0 {
+				return nil, 0, fmt.Errorf("warptest: unexpected ')' in %q", marble)
+			}
+			group = -1
+		default:
+			if terminated {
+				return nil, 0, fmt.Errorf("warptest: %q after termination in %q", r, marble)
+			}
+
+			n := notification[A]{frame: at, key: r}
+
+			switch r {
+			case '|':
+				n.kind, terminated = completion, true
+			case '!':
+				n.kind, terminated = cancellation, true
+			case '#':
+				if err == nil {
+					return nil, 0, fmt.Errorf("warptest: no error for '#' in %q", marble)
+				}
+				n.kind, n.err = failure, err
+			default:
+				v, ok := lookup(values, r)
+				if !ok {
+					return nil, 0, fmt.Errorf("warptest: no value for %q in %q", r, marble)
+				}
+				n.value = v
+			}
+
+			ns = append(ns, n)
+		}
+
+		frame++
+	}
+
+	if group >= 0 {
+		return nil, 0, fmt.Errorf("warptest: unclosed group in %q", marble)
+	}
+
+	return ns, frame, nil
+}
+
+// lookup returns the value of a key, which defaults to the key itself as a string
+// if A is a string type.
+func lookup[A any](values map[rune]A, r rune) (A, bool) {
+	if v, ok := values[r]; ok {
+		return v, true
+	}
+	v, ok := any(string(r)).(A)
+	return v, ok
+}
+
+// keyOf returns the smallest key of a value, or '?' if it has none.
+func keyOf[A any](values map[rune]A, a A) rune {
+	key := rune(-1)
+	for k, v := range values {
+		if reflect.DeepEqual(v, a) && (key < 0 || k < key) {
+			key = k
+		}
+	}
+	if key >= 0 {
+		return key
+	}
+	if s, ok := any(a).(string); ok && len([]rune(s)) == 1 {
+		return []rune(s)[0]
+	}
+	return '?'
+}
+
+// format renders notifications as a marble.
+func format[A any](ns []notification[A]) string {
+	var (
+		b   strings.Builder
+		pos = 0
+	)
+
+	for i := 0; i < len(ns); {
+		j := i + 1
+		for j < len(ns) && ns[j].frame == ns[i].frame {
+			j++
+		}
+
+		for ; pos < ns[i].frame; pos++ {
+			b.WriteByte('-')
+		}
+
+		if j-i == 1 {
+			b.WriteRune(ns[i].symbol())
+			pos++
+		} else {
+			b.WriteByte('(')
+			for _, n := range ns[i:j] {
+				b.WriteRune(n.symbol())
+			}
+			b.WriteByte(')')
+			pos += j - i + 2
+		}
+
+		i = j
+	}
+
+	return b.String()
+}
+
+// equal reports whether two sequences of notifications are the same. Errors are
+// compared with errors.Is.
+func equal[A any](expected, actual []notification[A]) bool {
+	if len(expected) != len(actual) {
+		return false
+	}
+	for i, e := range expected {
+		a := actual[i]
+		if e.frame != a.frame || e.kind != a.kind {
+			return false
+		}
+		switch e.kind {
+		case next:
+			if !reflect.DeepEqual(e.value, a.value) {
+				return false
+			}
+		case failure:
+			if !errors.Is(a.err, e.err) {
+				return false
+			}
+		}
+	}
+	return true
+}
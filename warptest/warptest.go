@@ -0,0 +1,325 @@
+// Package warptest provides utilities for testing events and futures with marble
+// diagrams which are driven by virtual time.
+//
+// A marble describes what happens at each frame of virtual time, one character
+// per frame:
+//
+//	a       a value keyed by the character is emitted
+//	-       nothing happens
+//	#       an error is emitted (futures only)
+//	|       the event ends
+//	!       the context of the event is cancelled (expected marbles only)
+//	(ab)    everything in parentheses happens in the frame of the '('
+//
+// Spaces are ignored and can be used to align marbles.
+package warptest
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/clock"
+	"github.com/onur1/warp/clock/clocktest"
+	"github.com/onur1/warp/result"
+)
+
+const (
+	settleRounds   = 3
+	settleInterval = time.Microsecond * 100
+	settleMax      = 1000
+	drainTimeout   = time.Second
+)
+
+// A T is the subset of testing.TB that is used to report failures.
+type T interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// A Scheduler drives marbles with a virtual clock in which each frame lasts for
+// Frame.
+type Scheduler struct {
+	Clock *clocktest.Clock
+	Frame time.Duration
+}
+
+// NewScheduler creates a scheduler with a virtual clock where a frame lasts for
+// a millisecond.
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		Clock: clocktest.NewClock(time.Unix(0, 0)),
+		Frame: time.Millisecond,
+	}
+}
+
+// Frames returns the duration of n frames.
+func (s *Scheduler) Frames(n int) time.Duration {
+	return s.Frame * time.Duration(n)
+}
+
+// Context returns a copy of a context which carries the virtual clock.
+func (s *Scheduler) Context(ctx context.Context) context.Context {
+	return clock.WithClock(ctx, s.Clock)
+}
+
+// Cold creates an event which emits values as described by a marble, counting
+// frames from the time it is subscribed. Characters are mapped to values with
+// values, or taken as strings if A is a string type. It panics if the marble is
+// invalid.
+func Cold[A any](s *Scheduler, marble string, values map[rune]A) warp.Event[A] {
+	ns, err := parseCold(marble, values, nil)
+	if err != nil {
+		panic(err)
+	}
+	return cold(s, ns, func(n notification[A]) A {
+		return n.value
+	})
+}
+
+// ColdFuture is like Cold but it creates a future which emits err for each '#'.
+func ColdFuture[A any](s *Scheduler, marble string, values map[rune]A, err error) warp.Future[A] {
+	ns, e := parseCold(marble, values, err)
+	if e != nil {
+		panic(e)
+	}
+	return warp.Future[A](cold(s, ns, func(n notification[A]) warp.Result[A] {
+		if n.kind == failure {
+			return result.Error[A](n.err)
+		}
+		return result.Ok(n.value)
+	}))
+}
+
+// Expect subscribes to an event and reports a failure unless what it emits matches
+// an expected marble. The event is observed for as many frames as the expected
+// marble spans, and cancelled at the frame of a '!'.
+func Expect[A any](t T, s *Scheduler, fa warp.Event[A], marble string, values map[rune]A) bool {
+	t.Helper()
+
+	expected, length, err := parse(marble, values, nil)
+	if err != nil {
+		t.Errorf("%v", err)
+		return false
+	}
+
+	return expect(t, s, fa, expected, length, func(a A) notification[A] {
+		return notification[A]{kind: next, key: keyOf(values, a), value: a}
+	})
+}
+
+// ExpectFuture is like Expect but it observes a future, in which failing results
+// match the '#' characters of the expected marble if they are err.
+func ExpectFuture[A any](t T, s *Scheduler, fa warp.Future[A], marble string, values map[rune]A, err error) bool {
+	t.Helper()
+
+	expected, length, e := parse(marble, values, err)
+	if e != nil {
+		t.Errorf("%v", e)
+		return false
+	}
+
+	ctx := s.Context(context.Background())
+
+	return expect(t, s, warp.Event[warp.Result[A]](fa), expected, length, func(ra warp.Result[A]) notification[A] {
+		a, err := ra(ctx)
+		if err != nil {
+			return notification[A]{kind: failure, err: err}
+		}
+		return notification[A]{kind: next, key: keyOf(values, a), value: a}
+	})
+}
+
+// parseCold parses the marble of a cold event, in which a '!' is invalid.
+func parseCold[A any](marble string, values map[rune]A, err error) ([]notification[A], error) {
+	ns, _, e := parse(marble, values, err)
+	if e != nil {
+		return nil, e
+	}
+	for _, n := range ns {
+		if n.kind == cancellation {
+			return nil, fmt.Errorf("warptest: '!' in source marble %q", marble)
+		}
+	}
+	return ns, nil
+}
+
+func cold[A, B any](s *Scheduler, ns []notification[A], f func(notification[A]) B) warp.Event[B] {
+	return func(ctx context.Context, sub chan<- B) {
+		defer close(sub)
+
+		var done <-chan struct{}
+
+		if ctx != nil {
+			done = ctx.Done()
+		}
+
+		start := s.Clock.Now()
+
+		for _, n := range ns {
+			if d := start.Add(s.Frames(n.frame)).Sub(s.Clock.Now()); d > 0 {
+				timer := s.Clock.NewTimer(d)
+				select {
+				case <-done:
+					timer.Stop()
+					return
+				case <-timer.C():
+				}
+			}
+
+			if n.kind == completion {
+				return
+			}
+
+			select {
+			case <-done:
+				return
+			case sub <- f(n):
+			}
+		}
+
+		<-done
+	}
+}
+
+// A recorder collects notifications from an event at the current frame.
+type recorder[A any] struct {
+	mu        sync.Mutex
+	frame     int
+	ns        []notification[A]
+	cancelled bool
+	stopped   bool
+	finished  chan struct{}
+}
+
+func (r *recorder[A]) record(n notification[A]) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stopped {
+		return
+	}
+	n.frame = r.frame
+	r.ns = append(r.ns, n)
+}
+
+func (r *recorder[A]) len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.ns)
+}
+
+func (r *recorder[A]) isFinished() bool {
+	select {
+	case <-r.finished:
+		return true
+	default:
+		return false
+	}
+}
+
+func expect[A, B any](t T, s *Scheduler, fb warp.Event[B], expected []notification[A], length int, observe func(B) notification[A]) bool {
+	t.Helper()
+
+	cancelFrame := -1
+	for _, n := range expected {
+		if n.kind == cancellation {
+			cancelFrame = n.frame
+		}
+	}
+
+	ctx, cancel := context.WithCancel(s.Context(context.Background()))
+	defer cancel()
+
+	var (
+		r  = &recorder[A]{finished: make(chan struct{})}
+		bs = make(chan B)
+	)
+
+	if cancelFrame == 0 {
+		r.cancelled = true
+		cancel()
+	}
+
+	go fb(ctx, bs)
+
+	go func() {
+		defer close(r.finished)
+		for b := range bs {
+			r.record(observe(b))
+		}
+		r.mu.Lock()
+		k := completion
+		if r.cancelled {
+			k = cancellation
+		}
+		r.mu.Unlock()
+		r.record(notification[A]{kind: k})
+	}()
+
+	s.settle(r.len)
+
+	for f := 1; f < length && !r.isFinished(); f++ {
+		r.mu.Lock()
+		r.frame = f
+		if f == cancelFrame {
+			r.cancelled = true
+		}
+		r.mu.Unlock()
+
+		if f == cancelFrame {
+			cancel()
+		} else {
+			s.Clock.Advance(s.Frame)
+		}
+
+		s.settle(r.len)
+
+		if f == cancelFrame {
+			break
+		}
+	}
+
+	r.mu.Lock()
+	r.stopped = true
+	actual := r.ns
+	r.mu.Unlock()
+
+	cancel()
+
+	select {
+	case <-r.finished:
+	case <-time.After(drainTimeout):
+		t.Errorf("warptest: event did not end after its context was cancelled")
+		return false
+	}
+
+	if !equal(expected, actual) {
+		t.Errorf("warptest: marbles differ\nexpected: %s\nactual:   %s", format(expected), format(actual))
+		return false
+	}
+
+	return true
+}
+
+// settle waits until the system under test appears to be idle, which is when
+// the number of recorded notifications, pending timers and goroutines stays the
+// same for a few rounds.
+func (s *Scheduler) settle(recorded func() int) {
+	var (
+		last   [3]int
+		stable = 0
+	)
+	for i := 0; i < settleMax && stable < settleRounds; i++ {
+		runtime.Gosched()
+		time.Sleep(settleInterval)
+		cur := [3]int{recorded(), s.Clock.Len(), runtime.NumGoroutine()}
+		if cur == last {
+			stable++
+		} else {
+			last, stable = cur, 0
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package warptest_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/onur1/warp"
+	"github.com/onur1/warp/event"
+	"github.com/onur1/warp/future"
+	"github.com/onur1/warp/warptest"
+	"github.com/stretchr/testify/assert"
+)
+
+var errFailed = errors.New("failed")
+
+var values = map[rune]int{'a': 1, 'b': 2, 'c': 3, 'x': 2, 'y': 4, 'z': 6}
+
+func TestExpect(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		event    func(s *warptest.Scheduler) warp.Event[int]
+		expected string
+	}{
+		{
+			desc: "Cold",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return warptest.Cold(s, "-a-b--c|", values)
+			},
+			expected: "-a-b--c|",
+		},
+		{
+			desc: "Cold (group)",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return warptest.Cold(s, "(ab)-c|", values)
+			},
+			expected: "(ab)-c|",
+		},
+		{
+			desc: "Cold (never)",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return warptest.Cold(s, "-a--", values)
+			},
+			expected: "-a------",
+		},
+		{
+			desc: "Cancel",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return warptest.Cold(s, "-a-b-c|", values)
+			},
+			expected: "-a-!",
+		},
+		{
+			desc: "Map",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.Map(warptest.Cold(s, "-a-b--c|", values), double)
+			},
+			expected: "-x-y--z|",
+		},
+		{
+			desc: "Debounce",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.Debounce(warptest.Cold(s, "-a-b-----c|", values), s.Frames(3))
+			},
+			expected: "------b---(c|)",
+		},
+		{
+			desc: "Throttle",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.Throttle(warptest.Cold(s, "-a-b-----c|", values), s.Frames(3), event.ThrottleLeading)
+			},
+			expected: "-a-------c|",
+		},
+		{
+			desc: "Interval",
+			event: func(s *warptest.Scheduler) warp.Event[int] {
+				return event.Take(event.Count(event.Interval(s.Frames(2))), 3)
+			},
+			expected: "--a-b-(c|)",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := warptest.NewScheduler()
+			warptest.Expect(t, s, tC.event(s), tC.expected, values)
+		})
+	}
+}
+
+func TestExpectFuture(t *testing.T) {
+	s := warptest.NewScheduler()
+
+	warptest.ExpectFuture(
+		t,
+		s,
+		future.Map(warptest.ColdFuture(s, "-a-#-c|", values, errFailed), double),
+		"-x-#-z|",
+		values,
+		errFailed,
+	)
+}
+
+func TestExpectMismatch(t *testing.T) {
+	s := warptest.NewScheduler()
+	r := &reporter{}
+
+	ok := warptest.Expect(r, s, warptest.Cold(s, "-a-b|", values), "-a--b|", values)
+
+	assert.False(t, ok)
+	assert.Equal(t, []string{"warptest: marbles differ\nexpected: -a--b|\nactual:   -a-b|"}, r.errors)
+}
+
+func TestColdCancellation(t *testing.T) {
+	s := warptest.NewScheduler()
+
+	assert.Panics(t, func() {
+		warptest.Cold(s, "-a-!", values)
+	})
+	assert.Panics(t, func() {
+		warptest.ColdFuture(s, "-a-!", values, errFailed)
+	})
+}
+
+func TestStrings(t *testing.T) {
+	s := warptest.NewScheduler()
+
+	warptest.Expect(t, s, warptest.Cold[string](s, "-a-b|", nil), "-a-b|", nil)
+}
+
+type reporter struct {
+	errors []string
+}
+
+func (r *reporter) Helper() {}
+
+func (r *reporter) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func double(n int) int {
+	return n * 2
+}